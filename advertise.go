@@ -0,0 +1,60 @@
+package hraftdispatcher
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+)
+
+// advertiseAddr is the net.Addr of AdvertiseAddress, the host may be a hostname.
+type advertiseAddr string
+
+// Network implements the net.Addr interface.
+func (a advertiseAddr) Network() string {
+	return "tcp"
+}
+
+// String implements the net.Addr interface.
+func (a advertiseAddr) String() string {
+	return string(a)
+}
+
+// advertiseListener is a net.Listener whose Addr returns the advertise address,
+// so that raft and the HTTP service tell the other nodes the address they can reach.
+type advertiseListener struct {
+	net.Listener
+	addr net.Addr
+}
+
+// Addr implements the net.Listener interface.
+func (l *advertiseListener) Addr() net.Addr {
+	return l.addr
+}
+
+// validateAdvertiseAddress checks that the address has a host and a port, and that the host can be dialed by the other nodes.
+// A loopback host is accepted only if allowLoopback is true.
+func validateAdvertiseAddress(address string, allowLoopback bool) error {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("invalid AdvertiseAddress %s: %v", address, err)
+	}
+	if len(host) == 0 {
+		return fmt.Errorf("host is omitted in AdvertiseAddress %s", address)
+	}
+	if n, err := strconv.ParseUint(port, 10, 16); err != nil || n == 0 {
+		return fmt.Errorf("invalid port in AdvertiseAddress %s", address)
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		// a hostname, such as the name of a container.
+		return nil
+	}
+	if ip.IsUnspecified() || ip.IsMulticast() || ip.Equal(net.IPv4bcast) || ip.IsLinkLocalUnicast() {
+		return fmt.Errorf("AdvertiseAddress %s is not routable", address)
+	}
+	if ip.IsLoopback() && !allowLoopback {
+		return fmt.Errorf("AdvertiseAddress %s is a loopback address, set AllowLoopbackAdvertise to use it", address)
+	}
+	return nil
+}
@@ -0,0 +1,542 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+
+	"github.com/casbin/hraft-dispatcher/command"
+	hraft "github.com/casbin/hraft-dispatcher/http"
+)
+
+const (
+	// defaultRequestTimeout is used if the context of a call has no deadline.
+	defaultRequestTimeout = 10 * time.Second
+	// defaultMaxElapsedTime is the default time a call is retried for.
+	defaultMaxElapsedTime = 30 * time.Second
+	// maxRedirects is the maximum number of redirects followed by a single attempt.
+	maxRedirects = 3
+)
+
+// Config configures a Client.
+type Config struct {
+	// Addresses are the HTTP(S) addresses of the nodes, such as 10.1.1.19:6780.
+	// Any subset of the cluster works, the client finds the leader by the redirects of the nodes.
+	Addresses []string
+	// TLSConfig is used to talk to the nodes over HTTPS, the nodes are talked over HTTP if it is nil.
+	TLSConfig *tls.Config
+	// RequestTimeout bounds a call whose context has no deadline, 10 seconds by default.
+	RequestTimeout time.Duration
+	// MaxElapsedTime is the time a failed call is retried for, 30 seconds by default.
+	MaxElapsedTime time.Duration
+}
+
+// Client talks to a cluster over the HTTP API of its nodes without joining it.
+// The leader is cached, the calls are sent to it directly once it is known.
+// The calls failed because of the network or an unavailable cluster are retried with an exponential backoff,
+// the writes carry a request ID so that a retried write is applied only once.
+type Client struct {
+	addresses      []string
+	scheme         string
+	httpClient     *http.Client
+	requestTimeout time.Duration
+	maxElapsedTime time.Duration
+
+	mu     sync.Mutex
+	leader string
+	next   int
+}
+
+// New returns a Client.
+func New(config *Config) (*Client, error) {
+	if config == nil || len(config.Addresses) == 0 {
+		return nil, errors.New("the addresses of the nodes are not provided")
+	}
+
+	c := &Client{
+		addresses:      append([]string(nil), config.Addresses...),
+		scheme:         "http",
+		requestTimeout: config.RequestTimeout,
+		maxElapsedTime: config.MaxElapsedTime,
+		httpClient: &http.Client{
+			// the redirects are followed by the client to cache the leader.
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+	if config.TLSConfig != nil {
+		c.scheme = "https"
+		c.httpClient.Transport = &http.Transport{TLSClientConfig: config.TLSConfig}
+	}
+	if c.requestTimeout == 0 {
+		c.requestTimeout = defaultRequestTimeout
+	}
+	if c.maxElapsedTime == 0 {
+		c.maxElapsedTime = defaultMaxElapsedTime
+	}
+	return c, nil
+}
+
+// Leader returns the cached address of the leader, it is empty if the leader is unknown.
+func (c *Client) Leader() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.leader
+}
+
+// AddPolicies adds a set of rules.
+func (c *Client) AddPolicies(ctx context.Context, request *command.AddPoliciesRequest) (*command.ApplyResponse, error) {
+	return c.apply(ctx, http.MethodPut, "/policies/add", nil, request)
+}
+
+// RemovePolicies removes a set of rules.
+func (c *Client) RemovePolicies(ctx context.Context, request *command.RemovePoliciesRequest) (*command.ApplyResponse, error) {
+	return c.apply(ctx, http.MethodPut, "/policies/remove", nil, request)
+}
+
+// RemoveFilteredPolicy removes the rules that match a filter.
+func (c *Client) RemoveFilteredPolicy(ctx context.Context, request *command.RemoveFilteredPolicyRequest) (*command.ApplyResponse, error) {
+	return c.apply(ctx, http.MethodPut, "/policies/remove", typeQuery("filtered"), request)
+}
+
+// UpdatePolicy replaces a rule.
+func (c *Client) UpdatePolicy(ctx context.Context, request *command.UpdatePolicyRequest) (*command.ApplyResponse, error) {
+	return c.apply(ctx, http.MethodPut, "/policies/update", nil, request)
+}
+
+// UpdatePolicies replaces a set of rules.
+func (c *Client) UpdatePolicies(ctx context.Context, request *command.UpdatePoliciesRequest) (*command.ApplyResponse, error) {
+	return c.apply(ctx, http.MethodPut, "/policies/update", typeQuery("batch"), request)
+}
+
+// UpdateFilteredPolicies replaces the rules that match a filter.
+func (c *Client) UpdateFilteredPolicies(ctx context.Context, request *command.UpdateFilteredPoliciesRequest) (*command.ApplyResponse, error) {
+	return c.apply(ctx, http.MethodPut, "/policies/update", typeQuery("filtered"), request)
+}
+
+// ClearPolicy removes all rules.
+func (c *Client) ClearPolicy(ctx context.Context) (*command.ApplyResponse, error) {
+	return c.apply(ctx, http.MethodPut, "/policies/remove", typeQuery("all"), nil)
+}
+
+// Transaction applies a set of operations atomically.
+func (c *Client) Transaction(ctx context.Context, request *command.TransactionRequest) (*command.ApplyResponse, error) {
+	return c.apply(ctx, http.MethodPut, "/policies/txn", nil, request)
+}
+
+// SchedulePolicies adds a set of rules activated at a given time.
+func (c *Client) SchedulePolicies(ctx context.Context, request *command.SchedulePoliciesRequest) (*command.ApplyResponse, error) {
+	return c.apply(ctx, http.MethodPut, "/policies/pending/add", nil, request)
+}
+
+// ReschedulePolicies changes the activation time of a set of pending rules.
+func (c *Client) ReschedulePolicies(ctx context.Context, request *command.ReschedulePoliciesRequest) (*command.ApplyResponse, error) {
+	return c.apply(ctx, http.MethodPut, "/policies/pending/reschedule", nil, request)
+}
+
+// CancelScheduledPolicies removes a set of pending rules.
+func (c *Client) CancelScheduledPolicies(ctx context.Context, request *command.CancelScheduledPoliciesRequest) (*command.ApplyResponse, error) {
+	return c.apply(ctx, http.MethodPut, "/policies/pending/cancel", nil, request)
+}
+
+// ImportPolicies reads the policies from r in the given format and adds them in a single command,
+// the current policies are cleared first if replace is true.
+func (c *Client) ImportPolicies(ctx context.Context, r io.Reader, format string, replace bool) (*command.ApplyResponse, error) {
+	policies, err := hraft.ReadPolicies(r, format)
+	if err != nil {
+		return nil, err
+	}
+	mode := "merge"
+	if replace {
+		mode = "replace"
+	}
+	query := url.Values{"format": {hraft.PolicyFormatJSON}, "mode": {mode}}
+	return c.apply(ctx, http.MethodPost, "/policies/import", query, policies)
+}
+
+// ListPolicies returns the rules selected by the query.
+func (c *Client) ListPolicies(ctx context.Context, query *hraft.PolicyQuery) (*command.ListPoliciesResponse, error) {
+	var resp command.ListPoliciesResponse
+	err := c.doJSON(ctx, http.MethodGet, "/policies", query.Values(), nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ListGroupingPolicies returns the grouping rules selected by the query, the section of the query is ignored.
+func (c *Client) ListGroupingPolicies(ctx context.Context, query *hraft.PolicyQuery) (*command.ListPoliciesResponse, error) {
+	var resp command.ListPoliciesResponse
+	err := c.doJSON(ctx, http.MethodGet, "/policies/grouping", query.Values(), nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// PendingPolicies returns the rules waiting to be activated.
+func (c *Client) PendingPolicies(ctx context.Context) ([]*command.PendingPolicy, error) {
+	var resp command.PendingPoliciesResponse
+	err := c.doJSON(ctx, http.MethodGet, "/policies/pending", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Policies, nil
+}
+
+// ExportPolicies writes the policies to w in the given format.
+func (c *Client) ExportPolicies(ctx context.Context, w io.Writer, format string) error {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	resp, err := c.send(ctx, http.MethodGet, "/policies/export", url.Values{"format": {format}}, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
+
+// Revision returns the revision of the policies.
+func (c *Client) Revision(ctx context.Context) (uint64, error) {
+	var resp command.RevisionResponse
+	err := c.doJSON(ctx, http.MethodGet, "/policies/revision", nil, nil, &resp)
+	return resp.Revision, err
+}
+
+// Enforce decides whether a request is allowed, consistency is one of the hraft.Consistency* levels.
+func (c *Client) Enforce(ctx context.Context, consistency string, values ...string) (*command.EnforceResponse, error) {
+	var resp command.EnforceResponse
+	err := c.doJSON(ctx, http.MethodPost, "/enforce", consistencyQuery(consistency), &command.EnforceRequest{Values: values}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// BatchEnforce decides whether a set of requests are allowed, the responses are in the order of the requests.
+func (c *Client) BatchEnforce(ctx context.Context, consistency string, requests [][]string) ([]*command.EnforceResponse, error) {
+	batch := &command.BatchEnforceRequest{}
+	for _, values := range requests {
+		batch.Requests = append(batch.Requests, &command.EnforceRequest{Values: values})
+	}
+	var resp command.BatchEnforceResponse
+	err := c.doJSON(ctx, http.MethodPost, "/enforce/batch", consistencyQuery(consistency), batch, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Responses, nil
+}
+
+// Members returns the servers in the cluster with their health, they are gathered by the leader.
+func (c *Client) Members(ctx context.Context) (*command.ListNodesResponse, error) {
+	var resp command.ListNodesResponse
+	err := c.doJSON(ctx, http.MethodGet, "/nodes", nil, nil, &resp)
+	if err != nil {
+		return nil, unsupported(err, "listing the members")
+	}
+	return &resp, nil
+}
+
+// JoinNode adds a node with the given ID and raft address to the cluster,
+// the role is hraft.RoleVoter or hraft.RoleNonvoter, a node joins as a voter if it is empty.
+func (c *Client) JoinNode(ctx context.Context, id string, address string, role string) error {
+	return c.doJSON(ctx, http.MethodPut, "/nodes/join", nil, &command.AddNodeRequest{Id: id, Address: address, Role: role}, nil)
+}
+
+// PromoteNode makes the nonvoter with the given ID a voter.
+func (c *Client) PromoteNode(ctx context.Context, id string) error {
+	err := c.doJSON(ctx, http.MethodPut, "/nodes/promote", nil, &command.PromoteNodeRequest{Id: id}, nil)
+	return unsupported(err, "promoting a node")
+}
+
+// DemoteNode makes the voter with the given ID a nonvoter.
+func (c *Client) DemoteNode(ctx context.Context, id string) error {
+	err := c.doJSON(ctx, http.MethodPut, "/nodes/demote", nil, &command.DemoteNodeRequest{Id: id}, nil)
+	return unsupported(err, "demoting a node")
+}
+
+// RemoveNode removes a node with the given ID from the cluster.
+func (c *Client) RemoveNode(ctx context.Context, id string) error {
+	return c.doJSON(ctx, http.MethodPut, "/nodes/remove", nil, &command.RemoveNodeRequest{Id: id}, nil)
+}
+
+// TransferLeadership transfers the leadership to the server with the given ID,
+// or to the most up-to-date server if id is empty.
+func (c *Client) TransferLeadership(ctx context.Context, id string) error {
+	err := c.doJSON(ctx, http.MethodPut, "/nodes/transfer-leadership", nil, &command.TransferLeadershipRequest{Id: id}, nil)
+	if err == nil {
+		// the cached leader has stepped down.
+		c.setLeader("")
+	}
+	return unsupported(err, "leadership transfer")
+}
+
+// Barrier returns the index a node has to apply to observe all writes committed before the call.
+func (c *Client) Barrier(ctx context.Context) (uint64, error) {
+	var resp command.BarrierResponse
+	err := c.doJSON(ctx, http.MethodGet, "/reads/barrier", nil, nil, &resp)
+	return resp.Index, err
+}
+
+// TakeSnapshot takes a snapshot on the node that serves the call.
+func (c *Client) TakeSnapshot(ctx context.Context) (*command.SnapshotResponse, error) {
+	var resp command.SnapshotResponse
+	err := c.doJSON(ctx, http.MethodPost, "/snapshot", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// DownloadSnapshot takes a snapshot on the node that serves the call and writes it to w,
+// it returns the raft log index of the snapshot.
+func (c *Client) DownloadSnapshot(ctx context.Context, w io.Writer) (uint64, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	resp, err := c.send(ctx, http.MethodGet, "/snapshot", nil, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	index, err := strconv.ParseUint(resp.Header.Get(hraft.RaftIndexHeader), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	_, err = io.Copy(w, resp.Body)
+	return index, err
+}
+
+// Health checks if the node that serves the call knows the leader of the cluster.
+func (c *Client) Health(ctx context.Context) (*command.HealthResponse, error) {
+	var resp command.HealthResponse
+	err := c.doJSON(ctx, http.MethodGet, "/health", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Stats returns the stats of the node that serves the call.
+func (c *Client) Stats(ctx context.Context) (map[string]interface{}, error) {
+	var resp map[string]interface{}
+	err := c.doJSON(ctx, http.MethodGet, "/stats", nil, nil, &resp)
+	return resp, err
+}
+
+func typeQuery(t string) url.Values {
+	return url.Values{"type": {t}}
+}
+
+func consistencyQuery(consistency string) url.Values {
+	if len(consistency) == 0 {
+		return nil
+	}
+	return url.Values{"consistency": {consistency}}
+}
+
+// apply sends a write with a request ID, so that it is applied only once when it is retried.
+func (c *Client) apply(ctx context.Context, method string, path string, query url.Values, request interface{}) (*command.ApplyResponse, error) {
+	if _, ok := hraft.RequestID(ctx); !ok {
+		id, err := newRequestID()
+		if err != nil {
+			return nil, err
+		}
+		ctx = hraft.WithRequestID(ctx, id)
+	}
+
+	var resp command.ApplyResponse
+	err := c.doJSON(ctx, method, path, query, request, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func newRequestID() (string, error) {
+	b := make([]byte, 16)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func (c *Client) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, c.requestTimeout)
+}
+
+// doJSON sends the request encoded in JSON, and decodes the body of the response to resp if it is not nil.
+func (c *Client) doJSON(ctx context.Context, method string, path string, query url.Values, request interface{}, resp interface{}) error {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	var body []byte
+	if request != nil {
+		var err error
+		body, err = jsoniter.Marshal(request)
+		if err != nil {
+			return err
+		}
+	}
+
+	r, err := c.send(ctx, method, path, query, body)
+	if err != nil {
+		return err
+	}
+	defer r.Body.Close()
+	if resp == nil {
+		return nil
+	}
+
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+	return jsoniter.Unmarshal(data, resp)
+}
+
+// send sends the request until a node accepts it, and returns the successful response.
+// The request is retried with another node if the current one cannot be reached or the cluster is unavailable,
+// a failed response that is not caused by the availability of the cluster is returned as a *hraft.Error.
+func (c *Client) send(ctx context.Context, method string, path string, query url.Values, body []byte) (*http.Response, error) {
+	b := backoff.NewExponentialBackOff()
+	b.MaxElapsedTime = c.maxElapsedTime
+
+	var resp *http.Response
+	err := backoff.Retry(func() error {
+		var err error
+		resp, err = c.sendOnce(ctx, method, path, query, body)
+		if err != nil && ctx.Err() != nil {
+			return backoff.Permanent(err)
+		}
+		return err
+	}, backoff.WithContext(b, ctx))
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// sendOnce sends the request to the leader if it is known, otherwise to the next node,
+// and follows the redirects to the leader.
+func (c *Client) sendOnce(ctx context.Context, method string, path string, query url.Values, body []byte) (*http.Response, error) {
+	address := c.target()
+	for i := 0; ; i++ {
+		resp, err := c.do(ctx, method, address, path, query, body)
+		if err != nil {
+			c.failed(address)
+			return nil, err
+		}
+
+		switch {
+		case resp.StatusCode == http.StatusOK:
+			return resp, nil
+		case resp.StatusCode == http.StatusTemporaryRedirect && i < maxRedirects:
+			location, err := resp.Location()
+			resp.Body.Close()
+			if err != nil {
+				return nil, backoff.Permanent(err)
+			}
+			address = location.Host
+			c.setLeader(address)
+		case resp.StatusCode == http.StatusServiceUnavailable:
+			err = hraft.ReadError(resp)
+			resp.Body.Close()
+			c.failed(address)
+			return nil, err
+		default:
+			err = hraft.ReadError(resp)
+			resp.Body.Close()
+			return nil, backoff.Permanent(err)
+		}
+	}
+}
+
+func (c *Client) do(ctx context.Context, method string, address string, path string, query url.Values, body []byte) (*http.Response, error) {
+	u := url.URL{Scheme: c.scheme, Host: address, Path: path}
+	if len(query) != 0 {
+		u.RawQuery = query.Encode()
+	}
+	r, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, backoff.Permanent(err)
+	}
+	if body != nil {
+		r.Header.Set("Content-Type", "application/json")
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		r.Header.Set(hraft.RequestTimeoutHeader, time.Until(deadline).String())
+	}
+	if revision, ok := hraft.ExpectedRevision(ctx); ok {
+		r.Header.Set(hraft.ExpectedRevisionHeader, strconv.FormatUint(revision, 10))
+	}
+	if id, ok := hraft.RequestID(ctx); ok {
+		r.Header.Set(hraft.RequestIDHeader, id)
+	}
+
+	resp, err := c.httpClient.Do(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send the request to %s: %w", address, err)
+	}
+	return resp, nil
+}
+
+// unsupported describes the 404 of a route that the server does not serve, such as a node running an older version.
+func unsupported(err error, feature string) error {
+	if e, ok := err.(*hraft.Error); ok && e.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("the server does not support %s, upgrade the nodes of the cluster first", feature)
+	}
+	return err
+}
+
+// target returns the cached leader, or the next node if the leader is unknown.
+func (c *Client) target() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.leader) != 0 {
+		return c.leader
+	}
+	address := c.addresses[c.next%len(c.addresses)]
+	c.next++
+	return address
+}
+
+func (c *Client) setLeader(address string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.leader = address
+}
+
+// failed forgets the leader if it fails, so that the next attempt tries another node.
+func (c *Client) failed(address string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.leader == address {
+		c.leader = ""
+	}
+}
@@ -0,0 +1,132 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hashicorp/raft"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/casbin/hraft-dispatcher/command"
+	hraft "github.com/casbin/hraft-dispatcher/http"
+	"github.com/casbin/hraft-dispatcher/http/mocks"
+)
+
+func newTestService(t *testing.T, store hraft.Store) *hraft.Service {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	s, err := hraft.NewService(zap.NewExample(), ln, nil, store)
+	assert.NoError(t, err)
+	err = s.Start()
+	assert.NoError(t, err)
+	return s
+}
+
+func TestClient(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	leaderStore := mocks.NewMockStore(ctl)
+	leader := newTestService(t, leaderStore)
+	defer leader.Stop(context.Background())
+
+	followerStore := mocks.NewMockStore(ctl)
+	follower := newTestService(t, followerStore)
+	defer follower.Stop(context.Background())
+
+	// a node that cannot be reached.
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	unreachable := ln.Addr().String()
+	ln.Close()
+
+	c, err := New(&Config{Addresses: []string{unreachable, follower.Addr()}, MaxElapsedTime: 5 * time.Second})
+	assert.NoError(t, err)
+
+	request := &command.AddPoliciesRequest{
+		Sec:   "p",
+		PType: "p",
+		Rules: []*command.StringArray{{Items: []string{"role:admin", "/", "*"}}},
+	}
+	followerStore.EXPECT().AddPolicies(gomock.Any(), request).Return(nil, raft.ErrNotLeader)
+	followerStore.EXPECT().Leader().Return(false, leader.Addr())
+	var requestID string
+	leaderStore.EXPECT().AddPolicies(gomock.Any(), request).DoAndReturn(func(ctx context.Context, request *command.AddPoliciesRequest) (*command.ApplyResponse, error) {
+		requestID, _ = hraft.RequestID(ctx)
+		return &command.ApplyResponse{Index: 3, Effected: true}, nil
+	})
+	resp, err := c.AddPolicies(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), resp.Index)
+	assert.NotEmpty(t, requestID)
+	assert.Equal(t, leader.Addr(), c.Leader())
+
+	// the leader is cached, the follower is not asked again.
+	leaderStore.EXPECT().ClearPolicy(gomock.Any()).Return(nil, hraft.NewApplyError(assert.AnError))
+	_, err = c.ClearPolicy(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, err.(*hraft.Error).StatusCode)
+	assert.Equal(t, hraft.ErrorCodeApplyFailed, err.(*hraft.Error).Code)
+
+	leaderStore.EXPECT().EnforceEx("alice", "/", "GET").Return(true, []string{"alice", "/", "GET"}, nil)
+	enforceResp, err := c.Enforce(context.Background(), hraft.ConsistencyStale, "alice", "/", "GET")
+	assert.NoError(t, err)
+	assert.True(t, enforceResp.Allowed)
+
+	policies := []*command.Policy{{Sec: "p", PType: "p", Rule: []string{"role:admin", "/", "*"}}}
+	leaderStore.EXPECT().ForEachPolicy(gomock.Any()).DoAndReturn(func(fn func(policy *command.Policy) error) error {
+		return fn(policies[0])
+	}).Times(2)
+	leaderStore.EXPECT().Revision().Return(uint64(3))
+	listResp, err := c.ListPolicies(context.Background(), &hraft.PolicyQuery{PType: "p"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), listResp.Total)
+
+	var buf bytes.Buffer
+	err = c.ExportPolicies(context.Background(), &buf, hraft.PolicyFormatCSV)
+	assert.NoError(t, err)
+	assert.Equal(t, "p,role:admin,/,*\n", buf.String())
+
+	leaderStore.EXPECT().ImportPolicies(gomock.Any(), &command.ImportPoliciesRequest{Replace: true, Policies: policies}).Return(&command.ApplyResponse{Index: 4}, nil)
+	resp, err = c.ImportPolicies(context.Background(), strings.NewReader(buf.String()), hraft.PolicyFormatCSV, true)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(4), resp.Index)
+
+	leaderStore.EXPECT().Stats().Return(map[string]interface{}{"node_id": "leader"}, nil)
+	stats, err := c.Stats(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "leader", stats["node_id"])
+
+	// the client falls back to the other nodes once the leader is gone.
+	leader.Stop(context.Background())
+	followerStore.EXPECT().JoinNode("node-3", "127.0.0.1:6890", hraft.RoleNonvoter).Return(nil)
+	err = c.JoinNode(context.Background(), "node-3", "127.0.0.1:6890", hraft.RoleNonvoter)
+	assert.NoError(t, err)
+	assert.Empty(t, c.Leader())
+}
+
+func TestClientUnsupported(t *testing.T) {
+	// a node of an older version does not serve the routes.
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	server := &http.Server{Handler: http.NotFoundHandler()}
+	go server.Serve(ln)
+	defer server.Close()
+
+	c, err := New(&Config{Addresses: []string{ln.Addr().String()}, MaxElapsedTime: time.Second})
+	assert.NoError(t, err)
+
+	_, err = c.Members(context.Background())
+	assert.EqualError(t, err, "the server does not support listing the members, upgrade the nodes of the cluster first")
+	err = c.TransferLeadership(context.Background(), "node-2")
+	assert.EqualError(t, err, "the server does not support leadership transfer, upgrade the nodes of the cluster first")
+	err = c.PromoteNode(context.Background(), "node-2")
+	assert.EqualError(t, err, "the server does not support promoting a node, upgrade the nodes of the cluster first")
+}
@@ -0,0 +1,359 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/casbin/casbin/v2"
+	fileadapter "github.com/casbin/casbin/v2/persist/file-adapter"
+	"github.com/hashicorp/raft"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/yaml.v2"
+
+	hraftdispatcher "github.com/casbin/hraft-dispatcher"
+)
+
+// envPrefix is the prefix of the environment variables that override the configuration file.
+const envPrefix = "HRAFT_"
+
+// raftLogLevels are the levels of the raft logs.
+var raftLogLevels = map[string]bool{"trace": true, "debug": true, "info": true, "warn": true, "error": true}
+
+// Duration is a time.Duration written as a string like 500ms in YAML.
+type Duration time.Duration
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (d *Duration) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	err := unmarshal(&s)
+	if err != nil {
+		return err
+	}
+	duration, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(duration)
+	return nil
+}
+
+// TLS holds the certificates, it is the same as the tls section of the example.
+type TLS struct {
+	RootCert string `yaml:"rootCert"`
+	Cert     string `yaml:"cert"`
+	Key      string `yaml:"key"`
+}
+
+// Raft holds the knobs of raft.Config, a zero value keeps the default of raft.DefaultConfig.
+type Raft struct {
+	HeartbeatTimeout   Duration `yaml:"heartbeatTimeout"`
+	ElectionTimeout    Duration `yaml:"electionTimeout"`
+	CommitTimeout      Duration `yaml:"commitTimeout"`
+	LeaderLeaseTimeout Duration `yaml:"leaderLeaseTimeout"`
+	MaxAppendEntries   int      `yaml:"maxAppendEntries"`
+	TrailingLogs       uint64   `yaml:"trailingLogs"`
+	SnapshotInterval   Duration `yaml:"snapshotInterval"`
+	SnapshotThreshold  uint64   `yaml:"snapshotThreshold"`
+	ShutdownOnRemove   *bool    `yaml:"shutdownOnRemove"`
+	// LogLevel is the level of the raft logs, trace, debug, info, warn or error.
+	LogLevel string `yaml:"logLevel"`
+}
+
+// Config is the configuration of the server.
+// The sink of the dispatcher is not configurable, it needs an adapter that writes incremental changes,
+// and the server only has the file adapter, which supports neither adding nor removing a single rule.
+type Config struct {
+	ServerID      string `yaml:"serverID"`
+	DataDir       string `yaml:"dataDir"`
+	ListenAddress string `yaml:"listenAddress"`
+	// AdvertiseAddress is the address the other nodes use to reach the server, defaults to ListenAddress.
+	AdvertiseAddress string `yaml:"advertiseAddress"`
+	// AllowLoopbackAdvertise accepts a loopback advertiseAddress when every node runs on the same host.
+	AllowLoopbackAdvertise bool   `yaml:"allowLoopbackAdvertise"`
+	JoinAddress            string `yaml:"joinAddress"`
+	// JoinAddresses are asked in turn to join an existing cluster.
+	JoinAddresses []string `yaml:"joinAddresses"`
+	JoinTimeout   Duration `yaml:"joinTimeout"`
+	// Role is voter or nonvoter, a nonvoter serves reads without voting.
+	Role string `yaml:"role"`
+	// ModelFile is the path to the Casbin model.
+	ModelFile string `yaml:"modelFile"`
+	// BootstrapPolicyFile is the path to a Casbin policy file that seeds a new cluster.
+	BootstrapPolicyFile string `yaml:"bootstrapPolicyFile"`
+	// LogLevel is debug, info, warn or error.
+	LogLevel string `yaml:"logLevel"`
+	TLS      TLS    `yaml:"tls"`
+	Raft     Raft   `yaml:"raft"`
+}
+
+// loadConfig reads the configuration file if path is not empty, and overrides it by the environment variables.
+func loadConfig(path string) (*Config, error) {
+	config := &Config{LogLevel: "info"}
+	if len(path) != 0 {
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read the configuration file")
+		}
+		err = yaml.UnmarshalStrict(b, config)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal the configuration")
+		}
+	}
+
+	err := config.loadEnv(os.LookupEnv)
+	if err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// loadEnv overrides the configuration by the environment variables,
+// the name of a variable is envPrefix followed by the upper snake case of the YAML path, such as HRAFT_RAFT_ELECTION_TIMEOUT.
+func (c *Config) loadEnv(lookup func(key string) (string, bool)) error {
+	texts := map[string]*string{
+		"SERVER_ID":             &c.ServerID,
+		"DATA_DIR":              &c.DataDir,
+		"LISTEN_ADDRESS":        &c.ListenAddress,
+		"ADVERTISE_ADDRESS":     &c.AdvertiseAddress,
+		"JOIN_ADDRESS":          &c.JoinAddress,
+		"ROLE":                  &c.Role,
+		"MODEL_FILE":            &c.ModelFile,
+		"BOOTSTRAP_POLICY_FILE": &c.BootstrapPolicyFile,
+		"LOG_LEVEL":             &c.LogLevel,
+		"TLS_ROOT_CERT":         &c.TLS.RootCert,
+		"TLS_CERT":              &c.TLS.Cert,
+		"TLS_KEY":               &c.TLS.Key,
+		"RAFT_LOG_LEVEL":        &c.Raft.LogLevel,
+	}
+	for key, value := range texts {
+		if v, ok := lookup(envPrefix + key); ok {
+			*value = v
+		}
+	}
+
+	if v, ok := lookup(envPrefix + "JOIN_ADDRESSES"); ok {
+		c.JoinAddresses = nil
+		for _, address := range strings.Split(v, ",") {
+			if address = strings.TrimSpace(address); len(address) != 0 {
+				c.JoinAddresses = append(c.JoinAddresses, address)
+			}
+		}
+	}
+
+	durations := map[string]*Duration{
+		"JOIN_TIMEOUT":              &c.JoinTimeout,
+		"RAFT_HEARTBEAT_TIMEOUT":    &c.Raft.HeartbeatTimeout,
+		"RAFT_ELECTION_TIMEOUT":     &c.Raft.ElectionTimeout,
+		"RAFT_COMMIT_TIMEOUT":       &c.Raft.CommitTimeout,
+		"RAFT_LEADER_LEASE_TIMEOUT": &c.Raft.LeaderLeaseTimeout,
+		"RAFT_SNAPSHOT_INTERVAL":    &c.Raft.SnapshotInterval,
+	}
+	for key, value := range durations {
+		if v, ok := lookup(envPrefix + key); ok {
+			duration, err := time.ParseDuration(v)
+			if err != nil {
+				return errors.Wrapf(err, "invalid %s%s", envPrefix, key)
+			}
+			*value = Duration(duration)
+		}
+	}
+
+	numbers := map[string]*uint64{
+		"RAFT_TRAILING_LOGS":      &c.Raft.TrailingLogs,
+		"RAFT_SNAPSHOT_THRESHOLD": &c.Raft.SnapshotThreshold,
+	}
+	for key, value := range numbers {
+		if v, ok := lookup(envPrefix + key); ok {
+			n, err := strconv.ParseUint(v, 10, 64)
+			if err != nil {
+				return errors.Wrapf(err, "invalid %s%s", envPrefix, key)
+			}
+			*value = n
+		}
+	}
+
+	if v, ok := lookup(envPrefix + "RAFT_MAX_APPEND_ENTRIES"); ok {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return errors.Wrapf(err, "invalid %sRAFT_MAX_APPEND_ENTRIES", envPrefix)
+		}
+		c.Raft.MaxAppendEntries = n
+	}
+	if v, ok := lookup(envPrefix + "ALLOW_LOOPBACK_ADVERTISE"); ok {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return errors.Wrapf(err, "invalid %sALLOW_LOOPBACK_ADVERTISE", envPrefix)
+		}
+		c.AllowLoopbackAdvertise = b
+	}
+	if v, ok := lookup(envPrefix + "RAFT_SHUTDOWN_ON_REMOVE"); ok {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return errors.Wrapf(err, "invalid %sRAFT_SHUTDOWN_ON_REMOVE", envPrefix)
+		}
+		c.Raft.ShutdownOnRemove = &b
+	}
+	return nil
+}
+
+// validate checks the configuration before the server starts.
+func (c *Config) validate() error {
+	if len(c.DataDir) == 0 {
+		return errors.New("dataDir is not provided")
+	}
+	if len(c.ListenAddress) == 0 {
+		return errors.New("listenAddress is not provided")
+	}
+	if len(c.ModelFile) == 0 {
+		return errors.New("modelFile is not provided")
+	}
+	if _, err := os.Stat(c.ModelFile); err != nil {
+		return errors.Wrap(err, "invalid modelFile")
+	}
+	if len(c.BootstrapPolicyFile) != 0 {
+		if _, err := os.Stat(c.BootstrapPolicyFile); err != nil {
+			return errors.Wrap(err, "invalid bootstrapPolicyFile")
+		}
+	}
+	if _, err := parseLogLevel(c.LogLevel); err != nil {
+		return err
+	}
+	if len(c.Raft.LogLevel) != 0 && !raftLogLevels[strings.ToLower(c.Raft.LogLevel)] {
+		return fmt.Errorf("invalid logLevel of raft: %s", c.Raft.LogLevel)
+	}
+	if (len(c.TLS.Cert) == 0) != (len(c.TLS.Key) == 0) || (len(c.TLS.RootCert) == 0) != (len(c.TLS.Cert) == 0) {
+		return errors.New("rootCert, cert and key of tls must be provided together")
+	}
+
+	raftConfig := c.raftConfig()
+	raftConfig.LocalID = raft.ServerID(c.serverID())
+	return raft.ValidateConfig(raftConfig)
+}
+
+func (c *Config) serverID() string {
+	if len(c.ServerID) != 0 {
+		return c.ServerID
+	}
+	if len(c.AdvertiseAddress) != 0 {
+		return c.AdvertiseAddress
+	}
+	return c.ListenAddress
+}
+
+// raftConfig returns raft.DefaultConfig overridden by the configuration.
+func (c *Config) raftConfig() *raft.Config {
+	config := raft.DefaultConfig()
+	if c.Raft.HeartbeatTimeout != 0 {
+		config.HeartbeatTimeout = time.Duration(c.Raft.HeartbeatTimeout)
+	}
+	if c.Raft.ElectionTimeout != 0 {
+		config.ElectionTimeout = time.Duration(c.Raft.ElectionTimeout)
+	}
+	if c.Raft.CommitTimeout != 0 {
+		config.CommitTimeout = time.Duration(c.Raft.CommitTimeout)
+	}
+	if c.Raft.LeaderLeaseTimeout != 0 {
+		config.LeaderLeaseTimeout = time.Duration(c.Raft.LeaderLeaseTimeout)
+	}
+	if c.Raft.MaxAppendEntries != 0 {
+		config.MaxAppendEntries = c.Raft.MaxAppendEntries
+	}
+	if c.Raft.TrailingLogs != 0 {
+		config.TrailingLogs = c.Raft.TrailingLogs
+	}
+	if c.Raft.SnapshotInterval != 0 {
+		config.SnapshotInterval = time.Duration(c.Raft.SnapshotInterval)
+	}
+	if c.Raft.SnapshotThreshold != 0 {
+		config.SnapshotThreshold = c.Raft.SnapshotThreshold
+	}
+	if c.Raft.ShutdownOnRemove != nil {
+		config.ShutdownOnRemove = *c.Raft.ShutdownOnRemove
+	}
+	if len(c.Raft.LogLevel) != 0 {
+		config.LogLevel = strings.ToUpper(c.Raft.LogLevel)
+	}
+	return config
+}
+
+// tlsConfig loads the certificates like the example, nil is returned if TLS is not configured.
+func (c *Config) tlsConfig() (*tls.Config, error) {
+	if len(c.TLS.RootCert) == 0 {
+		return nil, nil
+	}
+
+	rootCA, err := ioutil.ReadFile(c.TLS.RootCert)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the root certificate")
+	}
+	rootCAPool := x509.NewCertPool()
+	if !rootCAPool.AppendCertsFromPEM(rootCA) {
+		return nil, errors.New("failed to parse the root certificate")
+	}
+	cert, err := tls.LoadX509KeyPair(c.TLS.Cert, c.TLS.Key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load the certificate")
+	}
+
+	return &tls.Config{
+		RootCAs:      rootCAPool,
+		ClientCAs:    rootCAPool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		Certificates: []tls.Certificate{cert},
+	}, nil
+}
+
+// dispatcherConfig returns the configuration of the dispatcher for the enforcer.
+func (c *Config) dispatcherConfig(e casbin.IDistributedEnforcer) (*hraftdispatcher.Config, error) {
+	tlsConfig, err := c.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	config := &hraftdispatcher.Config{
+		Enforcer:               e,
+		ServerID:               c.serverID(),
+		JoinAddress:            c.JoinAddress,
+		JoinAddresses:          c.JoinAddresses,
+		JoinTimeout:            time.Duration(c.JoinTimeout),
+		Role:                   c.Role,
+		DataDir:                c.DataDir,
+		ListenAddress:          c.ListenAddress,
+		AdvertiseAddress:       c.AdvertiseAddress,
+		AllowLoopbackAdvertise: c.AllowLoopbackAdvertise,
+		TLSConfig:              tlsConfig,
+		RaftConfig:             c.raftConfig(),
+	}
+	if len(c.BootstrapPolicyFile) != 0 {
+		config.BootstrapAdapter = fileadapter.NewAdapter(c.BootstrapPolicyFile)
+	}
+	return config, nil
+}
+
+func parseLogLevel(level string) (zapcore.Level, error) {
+	var l zapcore.Level
+	err := l.UnmarshalText([]byte(level))
+	if err != nil {
+		return l, fmt.Errorf("invalid logLevel: %s", level)
+	}
+	return l, nil
+}
+
+// newLogger returns a production logger with the level of the configuration.
+func newLogger(level string) (*zap.Logger, error) {
+	l, err := parseLogLevel(level)
+	if err != nil {
+		return nil, err
+	}
+	config := zap.NewProductionConfig()
+	config.Level = zap.NewAtomicLevelAt(l)
+	return config.Build()
+}
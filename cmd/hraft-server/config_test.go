@@ -0,0 +1,117 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testModelText = `
+[request_definition]
+r = sub, obj, act
+
+[policy_definition]
+p = sub, obj, act
+
+[policy_effect]
+e = some(where (p.eft == allow))
+
+[matchers]
+m = r.sub == p.sub && r.obj == p.obj && r.act == p.act
+`
+
+func TestLoadConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hraft-server-")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	modelFile := filepath.Join(dir, "model.conf")
+	err = ioutil.WriteFile(modelFile, []byte(testModelText), 0600)
+	assert.NoError(t, err)
+
+	configFile := filepath.Join(dir, "config.yml")
+	err = ioutil.WriteFile(configFile, []byte(`
+serverID: node-1
+dataDir: `+dir+`
+listenAddress: 127.0.0.1:6780
+modelFile: `+modelFile+`
+logLevel: debug
+raft:
+  heartbeatTimeout: 500ms
+  electionTimeout: 500ms
+  snapshotThreshold: 1024
+  logLevel: warn
+`), 0600)
+	assert.NoError(t, err)
+
+	config, err := loadConfig(configFile)
+	assert.NoError(t, err)
+	assert.NoError(t, config.validate())
+	assert.Equal(t, "node-1", config.ServerID)
+	raftConfig := config.raftConfig()
+	assert.Equal(t, 500*time.Millisecond, raftConfig.HeartbeatTimeout)
+	assert.Equal(t, uint64(1024), raftConfig.SnapshotThreshold)
+	assert.Equal(t, "WARN", raftConfig.LogLevel)
+
+	env := map[string]string{
+		"HRAFT_SERVER_ID":                "node-2",
+		"HRAFT_RAFT_ELECTION_TIMEOUT":    "2s",
+		"HRAFT_RAFT_SHUTDOWN_ON_REMOVE":  "false",
+		"HRAFT_JOIN_ADDRESSES":           "127.0.0.1:6790, 127.0.0.1:6800",
+		"HRAFT_JOIN_TIMEOUT":             "30s",
+		"HRAFT_ADVERTISE_ADDRESS":        "10.0.0.1:6780",
+		"HRAFT_ALLOW_LOOPBACK_ADVERTISE": "true",
+		"HRAFT_RAFT_LOG_LEVEL":           "error",
+	}
+	err = config.loadEnv(func(key string) (string, bool) {
+		value, ok := env[key]
+		return value, ok
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "node-2", config.ServerID)
+	raftConfig = config.raftConfig()
+	assert.Equal(t, 2*time.Second, raftConfig.ElectionTimeout)
+	assert.False(t, raftConfig.ShutdownOnRemove)
+	assert.Equal(t, "ERROR", raftConfig.LogLevel)
+
+	dispatcherConfig, err := config.dispatcherConfig(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "node-2", dispatcherConfig.ServerID)
+	assert.Equal(t, []string{"127.0.0.1:6790", "127.0.0.1:6800"}, dispatcherConfig.JoinAddresses)
+	assert.Equal(t, 30*time.Second, dispatcherConfig.JoinTimeout)
+	assert.Equal(t, "10.0.0.1:6780", dispatcherConfig.AdvertiseAddress)
+	assert.True(t, dispatcherConfig.AllowLoopbackAdvertise)
+	assert.Nil(t, dispatcherConfig.TLSConfig)
+
+	// the election timeout is shorter than the heartbeat timeout.
+	config.Raft.HeartbeatTimeout = Duration(time.Second)
+	config.Raft.ElectionTimeout = Duration(100 * time.Millisecond)
+	assert.Error(t, config.validate())
+
+	config.Raft = Raft{LogLevel: "verbose"}
+	assert.Error(t, config.validate())
+
+	config.Raft = Raft{}
+	config.LogLevel = "verbose"
+	assert.Error(t, config.validate())
+
+	config.LogLevel = "info"
+	config.TLS.Cert = "peer.pem"
+	assert.Error(t, config.validate())
+
+	err = ioutil.WriteFile(configFile, []byte("listenAdress: 127.0.0.1:6780\n"), 0600)
+	assert.NoError(t, err)
+	_, err = loadConfig(configFile)
+	assert.Error(t, err)
+}
+
+func TestLoadConfig_Example(t *testing.T) {
+	config, err := loadConfig("config.example.yml")
+	assert.NoError(t, err)
+	assert.NoError(t, config.validate())
+	assert.Equal(t, uint64(8192), config.raftConfig().SnapshotThreshold)
+}
@@ -0,0 +1,80 @@
+// Command hraft-server runs a node of a hraft-dispatcher cluster.
+// The node serves the HTTP API of the dispatcher, including the enforcement routes /enforce and /enforce/batch,
+// so services that do not embed an enforcer can query the cluster.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/casbin/casbin/v2/model"
+	"go.uber.org/zap"
+
+	hraftdispatcher "github.com/casbin/hraft-dispatcher"
+)
+
+func main() {
+	var configFile string
+	flag.StringVar(&configFile, "config-file", "", "The path to the configuration file, the HRAFT_* environment variables override it.")
+	flag.Parse()
+
+	err := run(configFile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+}
+
+func run(configFile string) error {
+	config, err := loadConfig(configFile)
+	if err != nil {
+		return err
+	}
+	err = config.validate()
+	if err != nil {
+		return err
+	}
+
+	logger, err := newLogger(config.LogLevel)
+	if err != nil {
+		return err
+	}
+	defer logger.Sync()
+
+	m, err := model.NewModelFromFile(config.ModelFile)
+	if err != nil {
+		return err
+	}
+	// Adapter is not required here, the policies are maintained by the dispatcher.
+	e, err := casbin.NewDistributedEnforcer(m)
+	if err != nil {
+		return err
+	}
+
+	dispatcherConfig, err := config.dispatcherConfig(e)
+	if err != nil {
+		return err
+	}
+	dispatcher, err := hraftdispatcher.NewHRaftDispatcherWithLogger(dispatcherConfig, logger)
+	if err != nil {
+		return err
+	}
+	e.SetDispatcher(dispatcher)
+	logger.Info("the server is started", zap.String("serverID", dispatcherConfig.ServerID), zap.String("listenAddress", config.ListenAddress))
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+	sig := <-quit
+	logger.Info("the server is shutting down", zap.String("signal", sig.String()))
+
+	err = dispatcher.Shutdown()
+	if err != nil {
+		return err
+	}
+	logger.Info("the server is stopped")
+	return nil
+}
@@ -0,0 +1,537 @@
+// Command hraftctl administrates the policies and the nodes of a hraft-dispatcher cluster over its HTTP API.
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+
+	"github.com/casbin/hraft-dispatcher/client"
+	"github.com/casbin/hraft-dispatcher/command"
+	hraft "github.com/casbin/hraft-dispatcher/http"
+)
+
+const usage = `Usage: hraftctl [global flags] <command> [flags] [arguments]
+
+Commands:
+  policies list [-sec p] [-ptype p] [-field-index 0] [-field-values a,b] [-offset 0] [-limit 0] [-consistency stale]
+  policies add <ptype> <field>...
+  policies remove <ptype> <field>...
+  policies update -ptype <ptype> -old <field,...> -new <field,...>
+  policies import [-format csv] [-replace] <file|->
+  policies export [-format csv] [file]
+  nodes list
+  nodes join [-role voter] <id> <raft address>
+  nodes remove <id>
+  nodes promote <id>
+  nodes demote <id>
+  nodes transfer-leadership [id]
+  snapshot take
+  snapshot download <file>
+  stats
+  health
+
+Global flags:
+`
+
+// options are the global flags.
+type options struct {
+	addresses string
+	rootCert  string
+	cert      string
+	key       string
+	output    string
+	timeout   time.Duration
+}
+
+func main() {
+	err := run(os.Args[1:], os.Stdout)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+}
+
+// run runs the command in args and writes the output to stdout.
+func run(args []string, stdout io.Writer) error {
+	var opts options
+	fs := flag.NewFlagSet("hraftctl", flag.ContinueOnError)
+	fs.StringVar(&opts.addresses, "addresses", envOrDefault("HRAFTCTL_ADDRESSES", "127.0.0.1:6780"), "Comma-separated HTTP addresses of the nodes.")
+	fs.StringVar(&opts.rootCert, "root-cert", os.Getenv("HRAFTCTL_ROOT_CERT"), "The path to the root certificate, TLS is enabled if it is set.")
+	fs.StringVar(&opts.cert, "cert", os.Getenv("HRAFTCTL_CERT"), "The path to the client certificate.")
+	fs.StringVar(&opts.key, "key", os.Getenv("HRAFTCTL_KEY"), "The path to the key of the client certificate.")
+	fs.StringVar(&opts.output, "output", "table", "The output format, table or json.")
+	fs.DurationVar(&opts.timeout, "timeout", 30*time.Second, "The timeout of the command.")
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), usage)
+		fs.PrintDefaults()
+	}
+	err := fs.Parse(args)
+	if err != nil {
+		return err
+	}
+	if opts.output != "table" && opts.output != "json" {
+		return fmt.Errorf("unknown output format: %s", opts.output)
+	}
+
+	args = fs.Args()
+	if len(args) == 0 {
+		fs.Usage()
+		return errors.New("no command is given")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), opts.timeout)
+	defer cancel()
+
+	switch args[0] {
+	case "policies":
+		return runPolicies(ctx, &opts, args[1:], stdout)
+	case "nodes":
+		return runNodes(ctx, &opts, args[1:], stdout)
+	case "snapshot":
+		return runSnapshot(ctx, &opts, args[1:], stdout)
+	case "stats":
+		c, err := newClient(&opts, splitList(opts.addresses))
+		if err != nil {
+			return err
+		}
+		stats, err := c.Stats(ctx)
+		if err != nil {
+			return err
+		}
+		return printStats(&opts, stdout, stats)
+	case "health":
+		return runHealth(ctx, &opts, stdout)
+	default:
+		fs.Usage()
+		return fmt.Errorf("unknown command: %s", args[0])
+	}
+}
+
+func runPolicies(ctx context.Context, opts *options, args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		return errors.New("no policies command is given")
+	}
+	c, err := newClient(opts, splitList(opts.addresses))
+	if err != nil {
+		return err
+	}
+
+	fs := flag.NewFlagSet("policies "+args[0], flag.ContinueOnError)
+	switch args[0] {
+	case "list":
+		var query hraft.PolicyQuery
+		var fieldValues string
+		fs.StringVar(&query.Sec, "sec", "", "The section of the policies.")
+		fs.StringVar(&query.PType, "ptype", "", "The policy type of the policies.")
+		fs.IntVar(&query.FieldIndex, "field-index", 0, "The index of the first field matched by -field-values.")
+		fs.StringVar(&fieldValues, "field-values", "", "Comma-separated values of the fields from -field-index.")
+		fs.IntVar(&query.Offset, "offset", 0, "The number of the matched policies skipped.")
+		fs.IntVar(&query.Limit, "limit", 0, "The maximum number of the listed policies.")
+		fs.StringVar(&query.Consistency, "consistency", hraft.ConsistencyStale, "The consistency level, stale, leader or linearizable.")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if len(fieldValues) != 0 {
+			query.FieldValues = strings.Split(fieldValues, ",")
+		}
+		resp, err := c.ListPolicies(ctx, &query)
+		if err != nil {
+			return err
+		}
+		return printPolicies(opts, stdout, resp)
+	case "add", "remove":
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if fs.NArg() < 2 || len(fs.Arg(0)) == 0 {
+			return fmt.Errorf("usage: policies %s <ptype> <field>...", args[0])
+		}
+		pType := fs.Arg(0)
+		rules := []*command.StringArray{{Items: fs.Args()[1:]}}
+		var resp *command.ApplyResponse
+		if args[0] == "add" {
+			resp, err = c.AddPolicies(ctx, &command.AddPoliciesRequest{Sec: pType[:1], PType: pType, Rules: rules})
+		} else {
+			resp, err = c.RemovePolicies(ctx, &command.RemovePoliciesRequest{Sec: pType[:1], PType: pType, Rules: rules})
+		}
+		if err != nil {
+			return err
+		}
+		return printApplyResponse(opts, stdout, resp)
+	case "update":
+		var pType, oldRule, newRule string
+		fs.StringVar(&pType, "ptype", "p", "The policy type of the rule.")
+		fs.StringVar(&oldRule, "old", "", "Comma-separated fields of the old rule.")
+		fs.StringVar(&newRule, "new", "", "Comma-separated fields of the new rule.")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if len(pType) == 0 || len(oldRule) == 0 || len(newRule) == 0 {
+			return errors.New("usage: policies update -ptype <ptype> -old <field,...> -new <field,...>")
+		}
+		resp, err := c.UpdatePolicy(ctx, &command.UpdatePolicyRequest{
+			Sec:     pType[:1],
+			PType:   pType,
+			OldRule: strings.Split(oldRule, ","),
+			NewRule: strings.Split(newRule, ","),
+		})
+		if err != nil {
+			return err
+		}
+		return printApplyResponse(opts, stdout, resp)
+	case "import":
+		var format string
+		var replace bool
+		fs.StringVar(&format, "format", hraft.PolicyFormatCSV, "The format of the file, csv or json.")
+		fs.BoolVar(&replace, "replace", false, "Replace the current policies instead of merging them.")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if fs.NArg() != 1 {
+			return errors.New("usage: policies import [-format csv] [-replace] <file|->")
+		}
+		r := io.Reader(os.Stdin)
+		if fs.Arg(0) != "-" {
+			f, err := os.Open(fs.Arg(0))
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			r = f
+		}
+		resp, err := c.ImportPolicies(ctx, r, format, replace)
+		if err != nil {
+			return err
+		}
+		return printApplyResponse(opts, stdout, resp)
+	case "export":
+		var format string
+		fs.StringVar(&format, "format", hraft.PolicyFormatCSV, "The format of the file, csv or json.")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if fs.NArg() == 0 {
+			return c.ExportPolicies(ctx, stdout, format)
+		}
+		f, err := os.Create(fs.Arg(0))
+		if err != nil {
+			return err
+		}
+		err = c.ExportPolicies(ctx, f, format)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		return err
+	default:
+		return fmt.Errorf("unknown policies command: %s", args[0])
+	}
+}
+
+func runNodes(ctx context.Context, opts *options, args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		return errors.New("no nodes command is given")
+	}
+	c, err := newClient(opts, splitList(opts.addresses))
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "list":
+		resp, err := c.Members(ctx)
+		if err != nil {
+			return err
+		}
+		return printMembers(opts, stdout, resp)
+	case "join":
+		var role string
+		fs := flag.NewFlagSet("nodes join", flag.ContinueOnError)
+		fs.StringVar(&role, "role", hraft.RoleVoter, "The role of the node, voter or nonvoter.")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if fs.NArg() != 2 {
+			return errors.New("usage: nodes join [-role voter] <id> <raft address>")
+		}
+		err = c.JoinNode(ctx, fs.Arg(0), fs.Arg(1), role)
+	case "remove":
+		if len(args) != 2 {
+			return errors.New("usage: nodes remove <id>")
+		}
+		err = c.RemoveNode(ctx, args[1])
+	case "promote":
+		if len(args) != 2 {
+			return errors.New("usage: nodes promote <id>")
+		}
+		err = c.PromoteNode(ctx, args[1])
+	case "demote":
+		if len(args) != 2 {
+			return errors.New("usage: nodes demote <id>")
+		}
+		err = c.DemoteNode(ctx, args[1])
+	case "transfer-leadership":
+		if len(args) > 2 {
+			return errors.New("usage: nodes transfer-leadership [id]")
+		}
+		var id string
+		if len(args) == 2 {
+			id = args[1]
+		}
+		err = c.TransferLeadership(ctx, id)
+	default:
+		return fmt.Errorf("unknown nodes command: %s", args[0])
+	}
+	if err != nil {
+		return err
+	}
+	return printResult(opts, stdout, map[string]string{"result": "ok"}, "OK")
+}
+
+func runSnapshot(ctx context.Context, opts *options, args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		return errors.New("no snapshot command is given")
+	}
+	c, err := newClient(opts, splitList(opts.addresses))
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "take":
+		resp, err := c.TakeSnapshot(ctx)
+		if err != nil {
+			return err
+		}
+		return printResult(opts, stdout, resp, fmt.Sprintf("snapshot %s at index %d, %d bytes", resp.Id, resp.Index, resp.Size))
+	case "download":
+		if len(args) != 2 {
+			return errors.New("usage: snapshot download <file>")
+		}
+		f, err := os.Create(args[1])
+		if err != nil {
+			return err
+		}
+		index, err := c.DownloadSnapshot(ctx, f)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return err
+		}
+		return printResult(opts, stdout, &command.SnapshotResponse{Index: index}, fmt.Sprintf("snapshot at index %d saved to %s", index, args[1]))
+	default:
+		return fmt.Errorf("unknown snapshot command: %s", args[0])
+	}
+}
+
+// runHealth checks every node in the addresses, it fails if any of them is unhealthy.
+func runHealth(ctx context.Context, opts *options, stdout io.Writer) error {
+	type health struct {
+		Address string `json:"address"`
+		Healthy bool   `json:"healthy"`
+		Leader  bool   `json:"leader"`
+		Error   string `json:"error,omitempty"`
+	}
+
+	var results []health
+	var unhealthy int
+	for _, address := range splitList(opts.addresses) {
+		c, err := newClient(opts, []string{address})
+		if err != nil {
+			return err
+		}
+		result := health{Address: address}
+		resp, err := c.Health(ctx)
+		if err != nil {
+			result.Error = err.Error()
+			unhealthy++
+		} else {
+			result.Healthy = true
+			result.Leader = resp.Leader
+		}
+		results = append(results, result)
+	}
+
+	if opts.output == "json" {
+		err := printJSON(stdout, results)
+		if err != nil {
+			return err
+		}
+	} else {
+		tw := tabwriter.NewWriter(stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "ADDRESS\tHEALTHY\tLEADER\tERROR")
+		for _, result := range results {
+			fmt.Fprintf(tw, "%s\t%t\t%t\t%s\n", result.Address, result.Healthy, result.Leader, result.Error)
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+	if unhealthy != 0 {
+		return fmt.Errorf("%d of %d nodes are unhealthy", unhealthy, len(results))
+	}
+	return nil
+}
+
+func newClient(opts *options, addresses []string) (*client.Client, error) {
+	config := &client.Config{
+		Addresses:      addresses,
+		MaxElapsedTime: opts.timeout,
+	}
+	if len(opts.rootCert) != 0 {
+		tlsConfig, err := loadTLSConfig(opts)
+		if err != nil {
+			return nil, err
+		}
+		config.TLSConfig = tlsConfig
+	}
+	return client.New(config)
+}
+
+// loadTLSConfig loads the certificates like the tls section of the example configuration.
+func loadTLSConfig(opts *options) (*tls.Config, error) {
+	rootCA, err := ioutil.ReadFile(opts.rootCert)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the root certificate")
+	}
+	rootCAPool := x509.NewCertPool()
+	if !rootCAPool.AppendCertsFromPEM(rootCA) {
+		return nil, errors.New("failed to parse the root certificate")
+	}
+
+	tlsConfig := &tls.Config{RootCAs: rootCAPool}
+	if len(opts.cert) != 0 {
+		cert, err := tls.LoadX509KeyPair(opts.cert, opts.key)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load the client certificate")
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
+
+func printPolicies(opts *options, stdout io.Writer, resp *command.ListPoliciesResponse) error {
+	if opts.output == "json" {
+		return printJSON(stdout, resp)
+	}
+
+	tw := tabwriter.NewWriter(stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "SEC\tPTYPE\tRULE")
+	for _, policy := range resp.Policies {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", policy.Sec, policy.PType, strings.Join(policy.Rule, ", "))
+	}
+	err := tw.Flush()
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(stdout, "\n%d of %d policies, revision %d\n", len(resp.Policies), resp.Total, resp.Revision)
+	return err
+}
+
+func printMembers(opts *options, stdout io.Writer, resp *command.ListNodesResponse) error {
+	if opts.output == "json" {
+		return printJSON(stdout, resp)
+	}
+
+	tw := tabwriter.NewWriter(stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tADDRESS\tSUFFRAGE\tLEADER\tAPPLIED\tLAG\tLAST CONTACT\tERROR")
+	for _, member := range resp.Members {
+		lastContact := "never"
+		if member.LastContact >= 0 {
+			lastContact = (time.Duration(member.LastContact) * time.Millisecond).String()
+		}
+		if len(member.Error) != 0 {
+			lastContact = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%t\t%d\t%d\t%s\t%s\n", member.Id, member.Address, member.Suffrage, member.Leader,
+			member.AppliedIndex, member.Lag, lastContact, member.Error)
+	}
+	err := tw.Flush()
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(stdout, "\nlast index %d\n", resp.LastIndex)
+	return err
+}
+
+func printApplyResponse(opts *options, stdout io.Writer, resp *command.ApplyResponse) error {
+	return printResult(opts, stdout, resp, fmt.Sprintf("applied at index %d, effected: %t", resp.Index, resp.Effected))
+}
+
+func printStats(opts *options, stdout io.Writer, stats map[string]interface{}) error {
+	if opts.output == "json" {
+		return printJSON(stdout, stats)
+	}
+
+	tw := tabwriter.NewWriter(stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "KEY\tVALUE")
+	for _, key := range sortedKeys(stats) {
+		value := stats[key]
+		if _, ok := value.(string); !ok {
+			b, err := jsoniter.Marshal(value)
+			if err != nil {
+				return err
+			}
+			value = string(b)
+		}
+		fmt.Fprintf(tw, "%s\t%s\n", key, value)
+	}
+	return tw.Flush()
+}
+
+// printResult prints v in JSON, or the text in the table format.
+func printResult(opts *options, stdout io.Writer, v interface{}, text string) error {
+	if opts.output == "json" {
+		return printJSON(stdout, v)
+	}
+	_, err := fmt.Fprintln(stdout, text)
+	return err
+}
+
+func printJSON(stdout io.Writer, v interface{}) error {
+	b, err := jsoniter.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(stdout, string(b))
+	return err
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); len(item) != 0 {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func envOrDefault(key string, value string) string {
+	if v, ok := os.LookupEnv(key); ok {
+		return v
+	}
+	return value
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/casbin/hraft-dispatcher/command"
+	hraft "github.com/casbin/hraft-dispatcher/http"
+	"github.com/casbin/hraft-dispatcher/http/mocks"
+)
+
+func TestRun(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	store := mocks.NewMockStore(ctl)
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	s, err := hraft.NewService(zap.NewExample(), ln, nil, store)
+	assert.NoError(t, err)
+	err = s.Start()
+	assert.NoError(t, err)
+	defer s.Stop(context.Background())
+
+	store.EXPECT().AddPolicies(gomock.Any(), &command.AddPoliciesRequest{
+		Sec:   "p",
+		PType: "p",
+		Rules: []*command.StringArray{{Items: []string{"alice", "/data", "GET"}}},
+	}).Return(&command.ApplyResponse{Index: 5, Effected: true}, nil)
+	var out bytes.Buffer
+	err = run([]string{"-addresses", s.Addr(), "policies", "add", "p", "alice", "/data", "GET"}, &out)
+	assert.NoError(t, err)
+	assert.Equal(t, "applied at index 5, effected: true\n", out.String())
+
+	store.EXPECT().ForEachPolicy(gomock.Any()).DoAndReturn(func(fn func(policy *command.Policy) error) error {
+		return fn(&command.Policy{Sec: "p", PType: "p", Rule: []string{"alice", "/data", "GET"}})
+	}).Times(2)
+	store.EXPECT().Revision().Return(uint64(5)).Times(2)
+	out.Reset()
+	err = run([]string{"-addresses", s.Addr(), "policies", "list", "-ptype", "p", "-field-values", "alice"}, &out)
+	assert.NoError(t, err)
+	assert.Equal(t, "SEC  PTYPE  RULE\np    p      alice, /data, GET\n\n1 of 1 policies, revision 5\n", out.String())
+
+	out.Reset()
+	err = run([]string{"-addresses", s.Addr(), "-output", "json", "policies", "list"}, &out)
+	assert.NoError(t, err)
+	assert.Contains(t, out.String(), `"revision": 5`)
+
+	store.EXPECT().Leader().Return(true, s.Addr())
+	out.Reset()
+	err = run([]string{"-addresses", s.Addr(), "health"}, &out)
+	assert.NoError(t, err)
+	assert.Contains(t, out.String(), "true")
+
+	store.EXPECT().JoinNode("node-2", "127.0.0.1:6791", hraft.RoleNonvoter).Return(nil)
+	out.Reset()
+	err = run([]string{"-addresses", s.Addr(), "nodes", "join", "-role", "nonvoter", "node-2", "127.0.0.1:6791"}, &out)
+	assert.NoError(t, err)
+	assert.Equal(t, "OK\n", out.String())
+
+	err = run([]string{"-addresses", s.Addr(), "policies", "add", "p"}, &out)
+	assert.Error(t, err)
+	err = run([]string{"-addresses", s.Addr(), "unknown"}, &out)
+	assert.Error(t, err)
+}
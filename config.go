@@ -2,7 +2,10 @@ package hraftdispatcher
 
 import (
 	"crypto/tls"
+	"time"
+
 	"github.com/casbin/casbin/v2"
+	"github.com/casbin/casbin/v2/persist"
 	"github.com/hashicorp/raft"
 )
 
@@ -14,11 +17,31 @@ type Config struct {
 	ServerID string
 	// JoinAddress is used to tells the current node to join an existing cluster.
 	JoinAddress string
+	// JoinAddresses are the HTTP addresses of some nodes of an existing cluster, the current node asks them
+	// in turn to join the cluster, and retries with exponential backoff until one of them succeeds.
+	// JoinAddress is asked first if it is also set, the address of the current node is skipped.
+	// A new node bootstraps a cluster if no address is left, such as the node whose JoinAddresses lists only itself.
+	JoinAddresses []string
+	// JoinTimeout bounds the time to join an existing cluster, defaults to one minute.
+	JoinTimeout time.Duration
+	// Role is the role the current node joins the cluster with, http.RoleVoter or http.RoleNonvoter.
+	// A nonvoter receives the policies and serves reads, but does not vote or count towards the quorum,
+	// so it adds read capacity without slowing down the writes. It is a voter by default.
+	Role string
 	// DataDir holds raft data.
 	DataDir string
 	// ListenAddress is a network address for raft server and HTTP(S) server,
 	// the address is a specified address, such as 10.1.1.19:6780.
+	// It may be an unspecified address, such as 0.0.0.0:6780, if AdvertiseAddress is set.
 	ListenAddress string
+	// AdvertiseAddress is the address the other nodes use to reach the current node, such as the address
+	// of the host of a container or a NAT gateway. It is the raft server address of the current node,
+	// and is used in the join requests and the redirects to the leader. It must have a routable host and a port,
+	// ListenAddress is advertised if it is not set.
+	AdvertiseAddress string
+	// AllowLoopbackAdvertise accepts a loopback AdvertiseAddress, such as 127.0.0.1:6780,
+	// it is only reachable when every node runs on the same host.
+	AllowLoopbackAdvertise bool
 	// TLSConfig is used to configure a TLS server and client.
 	// If TLSConfig is not nil, we will set TLSConfig to the raft server and the HTTPS server,
 	// otherwise we will start a server without any security.
@@ -29,4 +52,49 @@ type Config struct {
 	TLSConfig *tls.Config
 	// RaftConfig provides any necessary configuration for the Raft server.
 	RaftConfig *raft.Config
+	// BootstrapAdapter is used to seed a new cluster with the policies of an existing Casbin environment.
+	// The policies are loaded by the leader with the model of Enforcer, and added in a single command.
+	// The policies are added only once per cluster, the adapter is ignored after the cluster is bootstrapped.
+	BootstrapAdapter persist.Adapter
+	// SinkAdapter receives every change of the policies applied to the cluster in the order of the raft log,
+	// persist.BatchAdapter is used if it is implemented. The changes are delivered at least once,
+	// the index of the last exported change is saved in DataDir, so that the sink resumes after a restart.
+	// If some changes cannot be exported incrementally, such as after a snapshot is restored,
+	// all the policies are saved by SavePolicy.
+	SinkAdapter persist.Adapter
+	// SinkOnEveryNode exports the changes to SinkAdapter on every node, otherwise only the leader exports them.
+	SinkOnEveryNode bool
+}
+
+// defaultJoinTimeout is used if Config.JoinTimeout is not set.
+const defaultJoinTimeout = time.Minute
+
+// joinTimeout returns JoinTimeout or defaultJoinTimeout if it is not set.
+func (c *Config) joinTimeout() time.Duration {
+	if c.JoinTimeout == 0 {
+		return defaultJoinTimeout
+	}
+	return c.JoinTimeout
+}
+
+// advertiseAddress returns AdvertiseAddress or ListenAddress if it is not set.
+func (c *Config) advertiseAddress() string {
+	if len(c.AdvertiseAddress) == 0 {
+		return c.ListenAddress
+	}
+	return c.AdvertiseAddress
+}
+
+// joinAddresses returns JoinAddress and JoinAddresses without duplicates and the addresses of the current node.
+func (c *Config) joinAddresses() []string {
+	var addresses []string
+	seen := map[string]bool{c.ListenAddress: true, c.advertiseAddress(): true}
+	for _, address := range append([]string{c.JoinAddress}, c.JoinAddresses...) {
+		if len(address) == 0 || seen[address] {
+			continue
+		}
+		seen[address] = true
+		addresses = append(addresses, address)
+	}
+	return addresses
 }
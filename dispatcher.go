@@ -4,12 +4,17 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
+	"io"
 	"net"
+	"sort"
+	"sync/atomic"
+	"time"
 
 	"github.com/soheilhy/cmux"
 
 	"github.com/hashicorp/go-multierror"
 
+	"github.com/casbin/casbin/v2"
 	"github.com/casbin/casbin/v2/persist"
 	"github.com/casbin/hraft-dispatcher/command"
 	"github.com/casbin/hraft-dispatcher/http"
@@ -23,7 +28,10 @@ var _ persist.Dispatcher = &HRaftDispatcher{}
 
 // HRaftDispatcher implements the persist.Dispatcher interface.
 type HRaftDispatcher struct {
-	store       http.Store
+	// lastIndex is accessed atomically, keep it 64-bit aligned.
+	lastIndex uint64
+
+	store       *store.Store
 	tlsConfig   *tls.Config
 	httpService *http.Service
 	shutdownFn  func() error
@@ -55,24 +63,40 @@ func NewHRaftDispatcherWithLogger(config *Config, logger *zap.Logger) (*HRaftDis
 	}
 
 	if len(config.ServerID) == 0 {
-		config.ServerID = config.ListenAddress
+		config.ServerID = config.advertiseAddress()
 	}
 
 	if logger == nil {
 		return nil, errors.New("no logger provided")
 	}
 
+	role, err := http.ParseRole(config.Role)
+	if err != nil {
+		return nil, err
+	}
+	joinAddresses := config.joinAddresses()
+	if role == http.RoleNonvoter && len(joinAddresses) == 0 {
+		return nil, errors.New("a nonvoter must join an existing cluster by JoinAddress or JoinAddresses")
+	}
+
 	// check ListenAddress is network address
 	listenAddress, err := net.ResolveTCPAddr("tcp", config.ListenAddress)
 	if err != nil {
 		return nil, err
 	}
-	if listenAddress.IP == nil {
-		return nil, errors.New("host is omitted in ListenAddress")
-	}
-	ip := net.ParseIP(listenAddress.IP.String())
-	if ip != nil && ip.IsUnspecified() {
-		return nil, fmt.Errorf("cannot use unspecified IP %s", ip)
+	if len(config.AdvertiseAddress) == 0 {
+		if listenAddress.IP == nil {
+			return nil, errors.New("host is omitted in ListenAddress")
+		}
+		ip := net.ParseIP(listenAddress.IP.String())
+		if ip != nil && ip.IsUnspecified() {
+			return nil, fmt.Errorf("cannot use unspecified IP %s", ip)
+		}
+	} else {
+		err = validateAdvertiseAddress(config.AdvertiseAddress, config.AllowLoopbackAdvertise)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	var ln net.Listener
@@ -90,6 +114,12 @@ func NewHRaftDispatcherWithLogger(config *Config, logger *zap.Logger) (*HRaftDis
 	raftLn := mux.Match(cmux.Any())
 	go mux.Serve()
 
+	if len(config.AdvertiseAddress) != 0 {
+		addr := advertiseAddr(config.AdvertiseAddress)
+		httpLn = &advertiseListener{Listener: httpLn, addr: addr}
+		raftLn = &advertiseListener{Listener: raftLn, addr: addr}
+	}
+
 	streamLayer, err := store.NewTCPStreamLayer(raftLn, config.TLSConfig)
 	if err != nil {
 		return nil, err
@@ -106,6 +136,13 @@ func NewHRaftDispatcherWithLogger(config *Config, logger *zap.Logger) (*HRaftDis
 		Enforcer:   config.Enforcer,
 		RaftConfig: config.RaftConfig,
 	}
+	if config.SinkAdapter != nil {
+		storeConfig.Sink = &store.SinkConfig{
+			Adapter:   config.SinkAdapter,
+			Model:     config.Enforcer.GetModel(),
+			EveryNode: config.SinkOnEveryNode,
+		}
+	}
 	s, err := store.NewStore(logger, storeConfig)
 	if err != nil {
 		logger.Error(err.Error())
@@ -119,7 +156,8 @@ func NewHRaftDispatcherWithLogger(config *Config, logger *zap.Logger) (*HRaftDis
 		enableBootstrap = true
 	}
 
-	if len(config.JoinAddress) != 0 {
+	// the addresses of the current node are skipped, a node that is only given its own address bootstraps.
+	if len(joinAddresses) != 0 {
 		enableBootstrap = false
 	}
 
@@ -142,11 +180,15 @@ func NewHRaftDispatcherWithLogger(config *Config, logger *zap.Logger) (*HRaftDis
 		}
 	}
 
-	if isNewCluster && config.JoinAddress != config.ListenAddress && len(config.JoinAddress) != 0 {
-		logger.Info("start joining the current node to existing cluster")
-		err = http.DoJoinNodeRequest(config.JoinAddress, config.ServerID, config.ListenAddress, config.TLSConfig)
+	if isNewCluster && len(joinAddresses) != 0 {
+		logger.Info("start joining the current node to existing cluster", zap.Strings("clusterAddresses", joinAddresses))
+		ctx, cancel := context.WithTimeout(context.Background(), config.joinTimeout())
+		err = http.DoJoinClusterRequest(ctx, joinAddresses, config.ServerID, config.advertiseAddress(), role, config.TLSConfig)
+		cancel()
 		if err != nil {
-			logger.Error("failed to join the current node to existing cluster", zap.String("nodeID", config.ServerID), zap.String("nodeAddress", config.ListenAddress), zap.String("clusterAddress", config.JoinAddress), zap.Error(err))
+			logger.Error("failed to join the current node to existing cluster", zap.String("nodeID", config.ServerID), zap.String("nodeAddress", config.advertiseAddress()), zap.Strings("clusterAddresses", joinAddresses), zap.Error(err))
+			_ = s.Stop()
+			_ = ln.Close()
 			return nil, err
 		}
 		logger.Info("the current node has joined to existing cluster")
@@ -172,6 +214,16 @@ func NewHRaftDispatcherWithLogger(config *Config, logger *zap.Logger) (*HRaftDis
 	h.shutdownFn = func() error {
 		var ret error
 
+		// step down first, so that the cluster does not wait for an election timeout to elect a new leader.
+		if s.IsLeader() {
+			err := s.TransferLeadership("")
+			if err != nil {
+				logger.Warn("failed to transfer the leadership before shutting down", zap.Error(err))
+			} else {
+				logger.Info("the leadership is transferred before shutting down")
+			}
+		}
+
 		err := s.Stop()
 		if err != nil {
 			ret = multierror.Append(ret, err)
@@ -190,13 +242,136 @@ func NewHRaftDispatcherWithLogger(config *Config, logger *zap.Logger) (*HRaftDis
 		return ret
 	}
 
+	if !isNewCluster {
+		err = h.updateAddress(config)
+		if err != nil {
+			logger.Error("failed to update the address of the current node", zap.String("nodeID", config.ServerID), zap.String("nodeAddress", config.advertiseAddress()), zap.Error(err))
+			_ = h.Shutdown()
+			return nil, err
+		}
+	}
+
+	if config.BootstrapAdapter != nil {
+		err = h.bootstrap(config.Enforcer, config.BootstrapAdapter)
+		if err != nil {
+			logger.Error("failed to bootstrap the cluster", zap.Error(err))
+			_ = h.Shutdown()
+			return nil, err
+		}
+	}
+
 	return h, nil
 }
 
-//
+// updateAddress asks the leader to update the address of the current node if it differs from the address
+// in the raft configuration restored from DataDir, such as after the node restarts on a new port.
+// Otherwise the leader keeps sending the log to the old address. The join addresses, the other servers
+// in the configuration and the current node are asked in turn, the last one serves the request
+// if the current node is elected as the leader.
+func (h *HRaftDispatcher) updateAddress(config *Config) error {
+	configuration, err := h.store.Configuration()
+	if err != nil {
+		return err
+	}
+
+	advertiseAddress := config.advertiseAddress()
+	var local *raft.Server
+	addresses := config.joinAddresses()
+	seen := map[string]bool{config.ListenAddress: true, advertiseAddress: true}
+	for _, address := range addresses {
+		seen[address] = true
+	}
+	for i, server := range configuration.Servers {
+		if server.ID == raft.ServerID(config.ServerID) {
+			local = &configuration.Servers[i]
+			continue
+		}
+		if !seen[string(server.Address)] {
+			seen[string(server.Address)] = true
+			addresses = append(addresses, string(server.Address))
+		}
+	}
+	if local == nil || local.Address == raft.ServerAddress(advertiseAddress) {
+		return nil
+	}
+	addresses = append(addresses, advertiseAddress)
+
+	// the current node keeps its role in the cluster.
+	role := http.RoleVoter
+	if local.Suffrage == raft.Nonvoter {
+		role = http.RoleNonvoter
+	}
+
+	h.logger.Info("the address of the current node is changed, updating it in the cluster", zap.String("oldAddress", string(local.Address)), zap.String("newAddress", advertiseAddress), zap.Strings("clusterAddresses", addresses))
+	ctx, cancel := context.WithTimeout(context.Background(), config.joinTimeout())
+	defer cancel()
+	err = http.DoJoinClusterRequest(ctx, addresses, config.ServerID, advertiseAddress, role, config.TLSConfig)
+	if err != nil {
+		return err
+	}
+	h.logger.Info("the address of the current node is updated in the cluster")
+	return nil
+}
+
+// bootstrap seeds the cluster with the policies loaded from the adapter if the current node is the leader,
+// and the cluster has not been bootstrapped.
+func (h *HRaftDispatcher) bootstrap(e casbin.IDistributedEnforcer, adapter persist.Adapter) error {
+	if isLeader, _ := h.store.Leader(); !isLeader {
+		return nil
+	}
+	bootstrapped, err := h.store.Bootstrapped()
+	if err != nil || bootstrapped {
+		return err
+	}
+
+	m := e.GetModel().Copy()
+	m.ClearPolicy()
+	err = adapter.LoadPolicy(m)
+	if err != nil {
+		return err
+	}
+
+	request := &command.BootstrapPoliciesRequest{}
+	for _, sec := range []string{"p", "g"} {
+		var pTypes []string
+		for pType := range m[sec] {
+			pTypes = append(pTypes, pType)
+		}
+		sort.Strings(pTypes)
+		for _, pType := range pTypes {
+			rules := m.GetPolicy(sec, pType)
+			if len(rules) == 0 {
+				continue
+			}
+			request.Policies = append(request.Policies, &command.AddPoliciesRequest{
+				Sec:   sec,
+				PType: pType,
+				Rules: newStringArrays(rules),
+			})
+		}
+	}
+
+	resp, err := h.store.BootstrapPolicies(context.Background(), request)
+	if err != nil {
+		return err
+	}
+	h.logger.Info("the cluster is bootstrapped", zap.Bool("effected", resp.Effected), zap.Int("policies", len(request.Policies)))
+	return nil
+}
 
 //AddPolicies implements the persist.Dispatcher interface.
 func (h *HRaftDispatcher) AddPolicies(sec string, pType string, rules [][]string) error {
+	_, err := h.AddPoliciesWithResult(sec, pType, rules)
+	return err
+}
+
+// AddPoliciesWithResult adds a set of rules, and returns the rules added by the cluster.
+func (h *HRaftDispatcher) AddPoliciesWithResult(sec string, pType string, rules [][]string) (*command.ApplyResponse, error) {
+	return h.AddPoliciesContext(context.Background(), sec, pType, rules)
+}
+
+// AddPoliciesContext adds a set of rules, the deadline and the cancellation of ctx are passed to the leader.
+func (h *HRaftDispatcher) AddPoliciesContext(ctx context.Context, sec string, pType string, rules [][]string) (*command.ApplyResponse, error) {
 	var items []*command.StringArray
 	for _, rule := range rules {
 		var item = &command.StringArray{Items: rule}
@@ -208,11 +383,38 @@ func (h *HRaftDispatcher) AddPolicies(sec string, pType string, rules [][]string
 		PType: pType,
 		Rules: items,
 	}
-	return h.httpService.DoAddPolicyRequest(addPolicyRequest)
+	return h.handleApplyResponse(h.httpService.DoAddPolicyRequest(ctx, addPolicyRequest))
+}
+
+// AddExpiringPoliciesContext adds a set of rules that are removed by the cluster at the given time.
+// expiresAt has the same length as rules, a zero time means the rule never expires.
+func (h *HRaftDispatcher) AddExpiringPoliciesContext(ctx context.Context, sec string, pType string, rules [][]string, expiresAt []time.Time) (*command.ApplyResponse, error) {
+	if len(expiresAt) != len(rules) {
+		return nil, errors.New("the number of expiration times does not match the number of rules")
+	}
+
+	request := &command.AddPoliciesRequest{
+		Sec:       sec,
+		PType:     pType,
+		Rules:     newStringArrays(rules),
+		ExpiresAt: newUnixTimes(expiresAt),
+	}
+	return h.handleApplyResponse(h.httpService.DoAddPolicyRequest(ctx, request))
 }
 
 // RemovePolicies implements the persist.Dispatcher interface.
 func (h *HRaftDispatcher) RemovePolicies(sec string, pType string, rules [][]string) error {
+	_, err := h.RemovePoliciesWithResult(sec, pType, rules)
+	return err
+}
+
+// RemovePoliciesWithResult removes a set of rules, and returns the rules removed by the cluster.
+func (h *HRaftDispatcher) RemovePoliciesWithResult(sec string, pType string, rules [][]string) (*command.ApplyResponse, error) {
+	return h.RemovePoliciesContext(context.Background(), sec, pType, rules)
+}
+
+// RemovePoliciesContext removes a set of rules, the deadline and the cancellation of ctx are passed to the leader.
+func (h *HRaftDispatcher) RemovePoliciesContext(ctx context.Context, sec string, pType string, rules [][]string) (*command.ApplyResponse, error) {
 	var items []*command.StringArray
 	for _, rule := range rules {
 		var item = &command.StringArray{Items: rule}
@@ -224,38 +426,82 @@ func (h *HRaftDispatcher) RemovePolicies(sec string, pType string, rules [][]str
 		PType: pType,
 		Rules: items,
 	}
-	return h.httpService.DoRemovePolicyRequest(request)
+	return h.handleApplyResponse(h.httpService.DoRemovePolicyRequest(ctx, request))
 }
 
 // RemoveFilteredPolicy implements the persist.Dispatcher interface.
 func (h *HRaftDispatcher) RemoveFilteredPolicy(sec string, pType string, fieldIndex int, fieldValues ...string) error {
+	_, err := h.RemoveFilteredPolicyWithResult(sec, pType, fieldIndex, fieldValues...)
+	return err
+}
+
+// RemoveFilteredPolicyWithResult removes the rules that match a pattern, and returns the rules removed by the cluster.
+func (h *HRaftDispatcher) RemoveFilteredPolicyWithResult(sec string, pType string, fieldIndex int, fieldValues ...string) (*command.ApplyResponse, error) {
+	return h.RemoveFilteredPolicyContext(context.Background(), sec, pType, fieldIndex, fieldValues...)
+}
+
+// RemoveFilteredPolicyContext removes the rules that match a pattern, the deadline and the cancellation of ctx are passed to the leader.
+func (h *HRaftDispatcher) RemoveFilteredPolicyContext(ctx context.Context, sec string, pType string, fieldIndex int, fieldValues ...string) (*command.ApplyResponse, error) {
 	request := &command.RemoveFilteredPolicyRequest{
 		Sec:         sec,
 		PType:       pType,
 		FieldIndex:  int32(fieldIndex),
 		FieldValues: fieldValues,
 	}
-	return h.httpService.DoRemoveFilteredPolicyRequest(request)
+	return h.handleApplyResponse(h.httpService.DoRemoveFilteredPolicyRequest(ctx, request))
 }
 
 // ClearPolicy implements the persist.Dispatcher interface.
 func (h *HRaftDispatcher) ClearPolicy() error {
-	return h.httpService.DoClearPolicyRequest()
+	_, err := h.ClearPolicyWithResult()
+	return err
+}
+
+// ClearPolicyWithResult clears all rules.
+func (h *HRaftDispatcher) ClearPolicyWithResult() (*command.ApplyResponse, error) {
+	return h.ClearPolicyContext(context.Background())
+}
+
+// ClearPolicyContext clears all rules, the deadline and the cancellation of ctx are passed to the leader.
+func (h *HRaftDispatcher) ClearPolicyContext(ctx context.Context) (*command.ApplyResponse, error) {
+	return h.handleApplyResponse(h.httpService.DoClearPolicyRequest(ctx))
 }
 
 // UpdatePolicy implements the persist.Dispatcher interface.
 func (h *HRaftDispatcher) UpdatePolicy(sec string, pType string, oldRule, newRule []string) error {
+	_, err := h.UpdatePolicyWithResult(sec, pType, oldRule, newRule)
+	return err
+}
+
+// UpdatePolicyWithResult replaces an existing rule, and returns the old rule if it is replaced.
+func (h *HRaftDispatcher) UpdatePolicyWithResult(sec string, pType string, oldRule, newRule []string) (*command.ApplyResponse, error) {
+	return h.UpdatePolicyContext(context.Background(), sec, pType, oldRule, newRule)
+}
+
+// UpdatePolicyContext replaces an existing rule, the deadline and the cancellation of ctx are passed to the leader.
+func (h *HRaftDispatcher) UpdatePolicyContext(ctx context.Context, sec string, pType string, oldRule, newRule []string) (*command.ApplyResponse, error) {
 	request := &command.UpdatePolicyRequest{
 		Sec:     sec,
 		PType:   pType,
 		OldRule: oldRule,
 		NewRule: newRule,
 	}
-	return h.httpService.DoUpdatePolicyRequest(request)
+	return h.handleApplyResponse(h.httpService.DoUpdatePolicyRequest(ctx, request))
 }
 
 // UpdateFilteredPolicies implements the persist.Dispatcher interface.
 func (h *HRaftDispatcher) UpdateFilteredPolicies(sec string, pType string, oldRules, newRules [][]string) error {
+	_, err := h.UpdateFilteredPoliciesWithResult(sec, pType, oldRules, newRules)
+	return err
+}
+
+// UpdateFilteredPoliciesWithResult replaces a set of existing rules, and returns the old rules if they are replaced.
+func (h *HRaftDispatcher) UpdateFilteredPoliciesWithResult(sec string, pType string, oldRules, newRules [][]string) (*command.ApplyResponse, error) {
+	return h.UpdateFilteredPoliciesContext(context.Background(), sec, pType, oldRules, newRules)
+}
+
+// UpdateFilteredPoliciesContext replaces a set of existing rules, the deadline and the cancellation of ctx are passed to the leader.
+func (h *HRaftDispatcher) UpdateFilteredPoliciesContext(ctx context.Context, sec string, pType string, oldRules, newRules [][]string) (*command.ApplyResponse, error) {
 	var olds []*command.StringArray
 	for _, rule := range oldRules {
 		var item = &command.StringArray{Items: rule}
@@ -273,11 +519,22 @@ func (h *HRaftDispatcher) UpdateFilteredPolicies(sec string, pType string, oldRu
 		OldRules: olds,
 		NewRules: news,
 	}
-	return h.httpService.DoUpdateFilteredPoliciesRequest(request)
+	return h.handleApplyResponse(h.httpService.DoUpdateFilteredPoliciesRequest(ctx, request))
 }
 
 // UpdatePolicies implements the persist.Dispatcher interface.
 func (h *HRaftDispatcher) UpdatePolicies(sec string, pType string, oldRules, newRules [][]string) error {
+	_, err := h.UpdatePoliciesWithResult(sec, pType, oldRules, newRules)
+	return err
+}
+
+// UpdatePoliciesWithResult replaces a set of existing rules, and returns the old rules if they are replaced.
+func (h *HRaftDispatcher) UpdatePoliciesWithResult(sec string, pType string, oldRules, newRules [][]string) (*command.ApplyResponse, error) {
+	return h.UpdatePoliciesContext(context.Background(), sec, pType, oldRules, newRules)
+}
+
+// UpdatePoliciesContext replaces a set of existing rules, the deadline and the cancellation of ctx are passed to the leader.
+func (h *HRaftDispatcher) UpdatePoliciesContext(ctx context.Context, sec string, pType string, oldRules, newRules [][]string) (*command.ApplyResponse, error) {
 	var olds []*command.StringArray
 	for _, rule := range oldRules {
 		var item = &command.StringArray{Items: rule}
@@ -296,18 +553,133 @@ func (h *HRaftDispatcher) UpdatePolicies(sec string, pType string, oldRules, new
 		OldRules: olds,
 		NewRules: news,
 	}
-	return h.httpService.DoUpdatePoliciesRequest(request)
+	return h.handleApplyResponse(h.httpService.DoUpdatePoliciesRequest(ctx, request))
+}
+
+// handleApplyResponse records the index of the applied write.
+func (h *HRaftDispatcher) handleApplyResponse(resp *command.ApplyResponse, err error) (*command.ApplyResponse, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		lastIndex := atomic.LoadUint64(&h.lastIndex)
+		if resp.Index <= lastIndex || atomic.CompareAndSwapUint64(&h.lastIndex, lastIndex, resp.Index) {
+			return resp, nil
+		}
+	}
+}
+
+// LastIndex returns the highest raft log index of the writes made through the current dispatcher.
+// The index can be passed to WaitForIndex on other nodes to read your writes.
+func (h *HRaftDispatcher) LastIndex() uint64 {
+	return atomic.LoadUint64(&h.lastIndex)
+}
+
+// Revision returns the revision of the policies applied to the current node.
+// Every write that changes the policies increases the revision by one.
+func (h *HRaftDispatcher) Revision() uint64 {
+	return h.store.Revision()
+}
+
+// Adapter returns a read-only persist.FilteredAdapter over the policies replicated to the current node,
+// so that Enforcer.LoadPolicy and Enforcer.LoadFilteredPolicy reload the policies without going through raft.
+// A store.Filter selects the rules loaded by LoadFilteredPolicy.
+func (h *HRaftDispatcher) Adapter() *store.Adapter {
+	return h.store.Adapter()
+}
+
+// ExportPolicies writes the policies applied to the current node to w in the given format,
+// which is http.PolicyFormatCSV or http.PolicyFormatJSON.
+func (h *HRaftDispatcher) ExportPolicies(w io.Writer, format string) error {
+	pw, err := http.NewPolicyWriter(w, format)
+	if err != nil {
+		return err
+	}
+	err = h.store.ForEachPolicy(pw.Write)
+	if err != nil {
+		return err
+	}
+	return pw.Close()
+}
+
+// ImportPoliciesContext reads the policies from r in the given format, and adds them in a single command.
+// If replace is true, the current policies are cleared first, including the pending ones.
+func (h *HRaftDispatcher) ImportPoliciesContext(ctx context.Context, r io.Reader, format string, replace bool) (*command.ApplyResponse, error) {
+	policies, err := http.ReadPolicies(r, format)
+	if err != nil {
+		return nil, err
+	}
+	return h.handleApplyResponse(h.httpService.DoImportPoliciesRequest(ctx, policies, replace))
+}
+
+// ResyncSink replaces all policies of Config.SinkAdapter with the policies applied to the current node.
+// The sink is resynced in the background, it returns an error if Config.SinkAdapter is not set.
+func (h *HRaftDispatcher) ResyncSink() error {
+	return h.store.ResyncSink()
+}
+
+// WithExpectedRevision returns a copy of ctx, the writes made with it by the *Context methods
+// are applied only if the revision of the policies equals the given revision.
+// Otherwise the writes fail with an http.Error whose Code is http.ErrorCodeConflict.
+func WithExpectedRevision(ctx context.Context, revision uint64) context.Context {
+	return http.WithExpectedRevision(ctx, revision)
+}
+
+// WithRequestID returns a copy of ctx, the writes made with it by the *Context methods carry the given request ID.
+// Retrying a write with the same request ID, for example after a timeout, returns the response of the applied
+// write with Duplicate set instead of applying it again.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return http.WithRequestID(ctx, id)
+}
+
+// WaitForIndex blocks until the current node has applied the given raft log index.
+func (h *HRaftDispatcher) WaitForIndex(ctx context.Context, index uint64) error {
+	return h.store.WaitForAppliedIndex(ctx, index)
 }
 
-// JoinNode joins a node to the current cluster.
+// JoinNode joins a node to the current cluster as a voter.
 func (h *HRaftDispatcher) JoinNode(serverID, serverAddress string) error {
+	return h.JoinNodeWithRole(serverID, serverAddress, http.RoleVoter)
+}
+
+// JoinNodeWithRole joins a node to the current cluster, the role is http.RoleVoter or http.RoleNonvoter.
+// A node joins as a voter if the role is empty.
+func (h *HRaftDispatcher) JoinNodeWithRole(serverID, serverAddress, role string) error {
 	request := &command.AddNodeRequest{
 		Id:      serverID,
 		Address: serverAddress,
+		Role:    role,
 	}
 	return h.httpService.DoJoinNodeRequest(request)
 }
 
+// Members returns the servers in the cluster with their health, they are gathered by the leader.
+// The health of a server that cannot be reached is not set, and its Error describes the failure.
+func (h *HRaftDispatcher) Members(ctx context.Context) ([]*command.Member, error) {
+	resp, err := h.httpService.DoListNodesRequest(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Members, nil
+}
+
+// PromoteNode makes a nonvoter a voter, it does nothing if the node is already a voter.
+func (h *HRaftDispatcher) PromoteNode(ctx context.Context, serverID string) error {
+	request := &command.PromoteNodeRequest{
+		Id: serverID,
+	}
+	return h.httpService.DoPromoteNodeRequest(ctx, request)
+}
+
+// DemoteNode makes a voter a nonvoter, it does nothing if the node is already a nonvoter.
+func (h *HRaftDispatcher) DemoteNode(ctx context.Context, serverID string) error {
+	request := &command.DemoteNodeRequest{
+		Id: serverID,
+	}
+	return h.httpService.DoDemoteNodeRequest(ctx, request)
+}
+
 // JoinNode joins a node from the current cluster.
 func (h *HRaftDispatcher) RemoveNode(serverID string) error {
 	request := &command.RemoveNodeRequest{
@@ -316,7 +688,28 @@ func (h *HRaftDispatcher) RemoveNode(serverID string) error {
 	return h.httpService.DoRemoveNodeRequest(request)
 }
 
+// TransferLeadership transfers the leadership to the server with the given ID,
+// or to the most up-to-date server if targetID is empty. It returns after the target server becomes the leader.
+func (h *HRaftDispatcher) TransferLeadership(ctx context.Context, targetID string) error {
+	request := &command.TransferLeadershipRequest{
+		Id: targetID,
+	}
+	return h.httpService.DoTransferLeadershipRequest(ctx, request)
+}
+
+// WaitForConsistentRead blocks until the current node has applied all the policies
+// committed by the leader before this call, the following reads from the enforcer
+// on the current node are linearizable.
+func (h *HRaftDispatcher) WaitForConsistentRead(ctx context.Context) error {
+	index, err := h.httpService.DoBarrierRequest(ctx)
+	if err != nil {
+		return err
+	}
+	return h.store.WaitForAppliedIndex(ctx, index)
+}
+
 // Shutdown is used to close the http and raft service.
+// If the current node is the leader, the leadership is transferred to another server first.
 func (h *HRaftDispatcher) Shutdown() error {
 	return h.shutdownFn()
 }
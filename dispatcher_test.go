@@ -1,18 +1,27 @@
 package hraftdispatcher
 
 import (
+	"bytes"
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"github.com/casbin/hraft-dispatcher/store/mocks"
 	"io/ioutil"
 	"os"
+	"path"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/casbin/casbin/v2"
 	"github.com/casbin/casbin/v2/model"
+	fileadapter "github.com/casbin/casbin/v2/persist/file-adapter"
+	"github.com/casbin/hraft-dispatcher/command"
+	"github.com/casbin/hraft-dispatcher/http"
+	"github.com/casbin/hraft-dispatcher/store"
 	. "github.com/smartystreets/goconvey/convey"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func ToGenericArray(arr []string) []interface{} {
@@ -128,6 +137,230 @@ func TestDispatcher(t *testing.T) {
 				}
 			})
 
+			Convey("test WaitForConsistentRead()", func() {
+				rule := []string{"role:user", "/", "GET"}
+				_, err := leaderEnforcer.AddPolicy(rule)
+				So(err, ShouldBeNil)
+
+				ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+				defer cancel()
+				err = followerDispatcher.WaitForConsistentRead(ctx)
+				So(err, ShouldBeNil)
+
+				ok, err := followerEnforcer.Enforce(ToGenericArray(rule)...)
+				So(err, ShouldBeNil)
+				So(ok, ShouldBeTrue)
+			})
+
+			Convey("test WaitForIndex()", func() {
+				rule := []string{"role:user", "/", "POST"}
+				_, err := leaderEnforcer.AddPolicy(rule)
+				So(err, ShouldBeNil)
+
+				index := leaderDispatcher.LastIndex()
+				So(index, ShouldBeGreaterThan, 0)
+
+				ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+				defer cancel()
+				err = followerDispatcher.WaitForIndex(ctx, index)
+				So(err, ShouldBeNil)
+
+				ok, err := followerEnforcer.Enforce(ToGenericArray(rule)...)
+				So(err, ShouldBeNil)
+				So(ok, ShouldBeTrue)
+			})
+
+			Convey("test RemoveFilteredPolicyWithResult()", func() {
+				rules := [][]string{
+					{"role:tmp", "/", "GET"},
+					{"role:tmp", "/", "POST"},
+				}
+				_, err := leaderEnforcer.AddPolicies(rules)
+				So(err, ShouldBeNil)
+
+				resp, err := followerDispatcher.RemoveFilteredPolicyWithResult("p", "p", 0, "role:tmp")
+				So(err, ShouldBeNil)
+				So(resp.Effected, ShouldBeTrue)
+				So(resp.EffectedRules, ShouldHaveLength, len(rules))
+				for i, rule := range resp.EffectedRules {
+					So(rule.Items, ShouldResemble, rules[i])
+				}
+
+				resp, err = followerDispatcher.RemoveFilteredPolicyWithResult("p", "p", 0, "role:tmp")
+				So(err, ShouldBeNil)
+				So(resp.Effected, ShouldBeFalse)
+				So(resp.EffectedRules, ShouldBeEmpty)
+			})
+
+			Convey("test AddPoliciesContext()", func() {
+				rule := []string{"role:user", "/", "PATCH"}
+
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				_, err := followerDispatcher.AddPoliciesContext(ctx, "p", "p", [][]string{rule})
+				So(err, ShouldNotBeNil)
+
+				ctx, cancel = context.WithTimeout(context.Background(), 3*time.Second)
+				defer cancel()
+				resp, err := followerDispatcher.AddPoliciesContext(ctx, "p", "p", [][]string{rule})
+				So(err, ShouldBeNil)
+				So(resp.Effected, ShouldBeTrue)
+
+				err = followerDispatcher.WaitForIndex(ctx, resp.Index)
+				So(err, ShouldBeNil)
+				ok, err := followerEnforcer.Enforce(ToGenericArray(rule)...)
+				So(err, ShouldBeNil)
+				So(ok, ShouldBeTrue)
+			})
+
+			Convey("test WithExpectedRevision()", func() {
+				revision := leaderDispatcher.Revision()
+				ctx := WithExpectedRevision(context.Background(), revision+1)
+				_, err := followerDispatcher.AddPoliciesContext(ctx, "p", "p", [][]string{{"role:user", "/", "HEAD"}})
+				So(err, ShouldNotBeNil)
+				httpErr, ok := err.(*http.Error)
+				So(ok, ShouldBeTrue)
+				So(httpErr.Code, ShouldEqual, http.ErrorCodeConflict)
+
+				ctx = WithExpectedRevision(context.Background(), revision)
+				resp, err := followerDispatcher.AddPoliciesContext(ctx, "p", "p", [][]string{{"role:user", "/", "HEAD"}})
+				So(err, ShouldBeNil)
+				So(resp.Revision, ShouldEqual, revision+1)
+			})
+
+			Convey("test AddExpiringPoliciesContext()", func() {
+				rule := []string{"role:oncall", "/", "GET"}
+				resp, err := followerDispatcher.AddExpiringPoliciesContext(context.Background(), "p", "p", [][]string{rule}, []time.Time{time.Now().Add(time.Second)})
+				So(err, ShouldBeNil)
+				So(resp.Effected, ShouldBeTrue)
+
+				ok, err := leaderEnforcer.Enforce(ToGenericArray(rule)...)
+				So(err, ShouldBeNil)
+				So(ok, ShouldBeTrue)
+
+				<-time.After(3 * time.Second)
+
+				ok, err = leaderEnforcer.Enforce(ToGenericArray(rule)...)
+				So(err, ShouldBeNil)
+				So(ok, ShouldBeFalse)
+				ok, err = followerEnforcer.Enforce(ToGenericArray(rule)...)
+				So(err, ShouldBeNil)
+				So(ok, ShouldBeFalse)
+			})
+
+			Convey("test SchedulePoliciesContext()", func() {
+				rule := []string{"role:contractor", "/", "GET"}
+				resp, err := followerDispatcher.SchedulePoliciesContext(context.Background(), "p", "p", [][]string{rule}, time.Now().Add(time.Second), time.Time{})
+				So(err, ShouldBeNil)
+				So(resp.Effected, ShouldBeTrue)
+
+				activateAt := time.Now().Add(time.Second)
+				_, err = followerDispatcher.SchedulePoliciesContext(context.Background(), "p", "p", [][]string{rule}, activateAt, activateAt.Add(-time.Second))
+				So(err, ShouldBeError, "the expiration time must be after the activation time")
+
+				canceled := []string{"role:contractor", "/", "POST"}
+				_, err = followerDispatcher.SchedulePoliciesContext(context.Background(), "p", "p", [][]string{canceled}, time.Now().Add(time.Second), time.Time{})
+				So(err, ShouldBeNil)
+				pending, err := leaderDispatcher.PendingPolicies()
+				So(err, ShouldBeNil)
+				So(pending, ShouldHaveLength, 2)
+
+				resp, err = followerDispatcher.CancelScheduledPoliciesContext(context.Background(), "p", "p", [][]string{canceled})
+				So(err, ShouldBeNil)
+				So(resp.Effected, ShouldBeTrue)
+
+				ok, err := leaderEnforcer.Enforce(ToGenericArray(rule)...)
+				So(err, ShouldBeNil)
+				So(ok, ShouldBeFalse)
+
+				<-time.After(3 * time.Second)
+
+				ok, err = leaderEnforcer.Enforce(ToGenericArray(rule)...)
+				So(err, ShouldBeNil)
+				So(ok, ShouldBeTrue)
+				ok, err = followerEnforcer.Enforce(ToGenericArray(rule)...)
+				So(err, ShouldBeNil)
+				So(ok, ShouldBeTrue)
+				ok, err = leaderEnforcer.Enforce(ToGenericArray(canceled)...)
+				So(err, ShouldBeNil)
+				So(ok, ShouldBeFalse)
+
+				pending, err = leaderDispatcher.PendingPolicies()
+				So(err, ShouldBeNil)
+				So(pending, ShouldBeEmpty)
+			})
+
+			Convey("test Transaction()", func() {
+				_, err := leaderEnforcer.AddPolicies([][]string{
+					{"role:tmp", "/", "GET"},
+					{"role:tmp", "/", "POST"},
+				})
+				So(err, ShouldBeNil)
+
+				txn := NewTransaction().
+					RemoveFilteredPolicy("p", "p", 0, "role:tmp").
+					AddPolicies("p", "p", [][]string{{"role:tmp", "/tmp", "GET"}})
+				resp, err := followerDispatcher.Transaction(context.Background(), txn)
+				So(err, ShouldBeNil)
+				So(resp.Effected, ShouldBeTrue)
+				So(resp.Results, ShouldHaveLength, 2)
+				So(resp.Results[0].EffectedRules, ShouldHaveLength, 2)
+				So(resp.Results[1].EffectedRules, ShouldHaveLength, 1)
+
+				ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+				defer cancel()
+				err = followerDispatcher.WaitForIndex(ctx, resp.Index)
+				So(err, ShouldBeNil)
+
+				ok, err := followerEnforcer.Enforce("role:tmp", "/", "GET")
+				So(err, ShouldBeNil)
+				So(ok, ShouldBeFalse)
+				ok, err = followerEnforcer.Enforce("role:tmp", "/tmp", "GET")
+				So(err, ShouldBeNil)
+				So(ok, ShouldBeTrue)
+			})
+
+			Convey("test Adapter()", func() {
+				e, err := casbin.NewEnforcer(followerEnforcer.GetModel().Copy(), followerDispatcher.Adapter())
+				So(err, ShouldBeNil)
+				ok, err := e.Enforce("role:tmp", "/tmp", "GET")
+				So(err, ShouldBeNil)
+				So(ok, ShouldBeTrue)
+
+				err = e.LoadFilteredPolicy(store.Filter{PTypes: []string{"p"}, FieldValues: []string{"role:tmp"}})
+				So(err, ShouldBeNil)
+				So(e.GetPolicy(), ShouldResemble, [][]string{{"role:tmp", "/tmp", "GET"}})
+				So(e.SavePolicy(), ShouldNotBeNil)
+			})
+
+			Convey("test ImportPoliciesContext()", func() {
+				_, err := leaderDispatcher.ImportPoliciesContext(context.Background(), strings.NewReader("p, role:import, /import, GET\n"), http.PolicyFormatCSV, false)
+				So(err, ShouldBeNil)
+				ok, err := leaderEnforcer.Enforce("role:tmp", "/tmp", "GET")
+				So(err, ShouldBeNil)
+				So(ok, ShouldBeTrue)
+
+				_, err = leaderDispatcher.ImportPoliciesContext(context.Background(), strings.NewReader("p, role:import, /import, POST\ng, alice, role:import\n"), http.PolicyFormatCSV, true)
+				So(err, ShouldBeNil)
+
+				ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+				defer cancel()
+				err = followerDispatcher.WaitForIndex(ctx, leaderDispatcher.LastIndex())
+				So(err, ShouldBeNil)
+
+				var buf bytes.Buffer
+				err = followerDispatcher.ExportPolicies(&buf, http.PolicyFormatCSV)
+				So(err, ShouldBeNil)
+				So(buf.String(), ShouldEqual, "g,alice,role:import\np,role:import,/import,POST\n")
+
+				ok, err = followerEnforcer.Enforce("alice", "/import", "POST")
+				So(err, ShouldBeNil)
+				So(ok, ShouldBeTrue)
+				ok, err = followerEnforcer.Enforce("role:tmp", "/tmp", "GET")
+				So(err, ShouldBeNil)
+				So(ok, ShouldBeFalse)
+			})
+
 			Convey("test ClearPolicy()", func() {
 				leaderEnforcer.ClearPolicy()
 
@@ -478,7 +711,7 @@ func getTLSConfig() (*tls.Config, error) {
 	return config, nil
 }
 
-func newNode(dataDir, raftListenAddress, joinAddress string) (casbin.IDistributedEnforcer, *HRaftDispatcher, error) {
+func newNode(dataDir, raftListenAddress, joinAddress string, options ...func(config *Config)) (casbin.IDistributedEnforcer, *HRaftDispatcher, error) {
 	var modelText = `
 [request_definition]
 r = sub, obj, act
@@ -515,13 +748,17 @@ m = g(r.sub, p.sub) && r.obj == p.obj && r.act == p.act
 		return nil, nil, err
 	}
 
-	dispatcher, err := NewHRaftDispatcher(&Config{
+	config := &Config{
 		Enforcer:      e,
 		JoinAddress:   joinAddress,
 		ListenAddress: raftListenAddress,
 		TLSConfig:     tlsConfig,
 		DataDir:       dir,
-	})
+	}
+	for _, option := range options {
+		option(config)
+	}
+	dispatcher, err := NewHRaftDispatcher(config)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -531,6 +768,254 @@ m = g(r.sub, p.sub) && r.obj == p.obj && r.act == p.act
 	return e, dispatcher, nil
 }
 
+func TestBootstrapAdapter(t *testing.T) {
+	dataDir, err := ioutil.TempDir("", "casbin-hraft-dispatcher-")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dataDir)
+
+	policyPath := path.Join(dataDir, "policy.csv")
+	err = ioutil.WriteFile(policyPath, []byte("p, role:admin, /, GET\ng, alice, role:admin\n"), 0644)
+	assert.NoError(t, err)
+
+	var nodeDir string
+	e, dispatcher, err := newNode(dataDir, "127.0.0.1:6800", "", func(config *Config) {
+		config.BootstrapAdapter = fileadapter.NewAdapter(policyPath)
+		nodeDir = config.DataDir
+	})
+	assert.NoError(t, err)
+
+	ok, err := e.Enforce("alice", "/", "GET")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(1), dispatcher.Revision())
+	dispatcher.Shutdown()
+
+	// the bootstrap command replayed after a restart does not drop the seeded policies.
+	e, dispatcher, err = newNode(dataDir, "127.0.0.1:6800", "", func(config *Config) {
+		config.BootstrapAdapter = fileadapter.NewAdapter(policyPath)
+		config.DataDir = nodeDir
+	})
+	assert.NoError(t, err)
+	defer dispatcher.Shutdown()
+
+	err = dispatcher.store.WaitLeader()
+	assert.NoError(t, err)
+	_, err = dispatcher.store.Barrier(context.Background())
+	assert.NoError(t, err)
+	ok, err = e.Enforce("alice", "/", "GET")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(1), dispatcher.Revision())
+}
+
+func TestBootstrapWithOwnJoinAddress(t *testing.T) {
+	dataDir, err := ioutil.TempDir("", "casbin-hraft-dispatcher-")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dataDir)
+
+	// every node is given the same list, the first node skips its own address and bootstraps.
+	joinAddresses := []string{"127.0.0.1:6890"}
+	_, leader, err := newNode(dataDir, "127.0.0.1:6890", "", func(config *Config) {
+		config.JoinAddresses = joinAddresses
+	})
+	assert.NoError(t, err)
+	defer leader.Shutdown()
+	assert.True(t, leader.store.IsLeader())
+
+	_, follower, err := newNode(dataDir, "127.0.0.1:6900", "", func(config *Config) {
+		config.JoinAddresses = joinAddresses
+	})
+	assert.NoError(t, err)
+	defer follower.Shutdown()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	members, err := follower.Members(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, members, 2)
+}
+
+func TestTransferLeadership(t *testing.T) {
+	dataDir, err := ioutil.TempDir("", "casbin-hraft-dispatcher-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dataDir)
+
+	_, leader, err := newNode(dataDir, "127.0.0.1:6930", "")
+	require.NoError(t, err)
+	defer leader.Shutdown()
+	_, follower, err := newNode(dataDir, "127.0.0.1:6940", "127.0.0.1:6930")
+	require.NoError(t, err)
+	_, other, err := newNode(dataDir, "127.0.0.1:6950", "127.0.0.1:6930")
+	require.NoError(t, err)
+	defer other.Shutdown()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	var members []*command.Member
+	require.Eventually(t, func() bool {
+		members, err = follower.Members(ctx)
+		return err == nil && len(members) == 3
+	}, 5*time.Second, 50*time.Millisecond)
+	assert.Equal(t, "127.0.0.1:6930", members[0].Id)
+	assert.True(t, members[0].Leader)
+	assert.False(t, members[1].Leader)
+	assert.False(t, members[2].Leader)
+
+	// the request is redirected to the leader.
+	err = follower.TransferLeadership(ctx, "127.0.0.1:6940")
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return follower.store.IsLeader() && !leader.store.IsLeader() && !other.store.IsLeader()
+	}, 5*time.Second, 50*time.Millisecond)
+
+	// the leader steps down before shutting down, one of the remaining nodes takes over.
+	follower.Shutdown()
+	require.Eventually(t, func() bool {
+		return leader.store.IsLeader() || other.store.IsLeader()
+	}, 5*time.Second, 50*time.Millisecond)
+}
+
+func TestRestartWithNewAddress(t *testing.T) {
+	dataDir, err := ioutil.TempDir("", "casbin-hraft-dispatcher-")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dataDir)
+
+	_, leader, err := newNode(dataDir, "127.0.0.1:6830", "")
+	assert.NoError(t, err)
+	defer leader.Shutdown()
+	_, follower, err := newNode(dataDir, "127.0.0.1:6840", "127.0.0.1:6830")
+	assert.NoError(t, err)
+	defer follower.Shutdown()
+
+	var nodeDir string
+	e, node, err := newNode(dataDir, "127.0.0.1:6850", "127.0.0.1:6830", func(config *Config) {
+		config.ServerID = "node-3"
+		nodeDir = config.DataDir
+	})
+	assert.NoError(t, err)
+	err = leader.AddPolicies("p", "p", [][]string{{"bob", "/", "GET"}})
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		ok, err := e.Enforce("bob", "/", "GET")
+		return err == nil && ok
+	}, 5*time.Second, 50*time.Millisecond)
+	node.Shutdown()
+
+	// the node restarts with the same ID and data, but on a new port.
+	e, node, err = newNode(dataDir, "127.0.0.1:6860", "", func(config *Config) {
+		config.ServerID = "node-3"
+		config.DataDir = nodeDir
+	})
+	assert.NoError(t, err)
+	defer node.Shutdown()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	members, err := leader.Members(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, members, 3)
+	for _, member := range members {
+		if member.Id == "node-3" {
+			assert.Equal(t, "127.0.0.1:6860", member.Address)
+			assert.Equal(t, http.RoleVoter, member.Suffrage)
+		}
+	}
+
+	// the leader replicates the log to the new address.
+	err = leader.AddPolicies("p", "p", [][]string{{"alice", "/", "GET"}})
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		ok, err := e.Enforce("alice", "/", "GET")
+		return err == nil && ok
+	}, 5*time.Second, 50*time.Millisecond)
+}
+
+func TestRestartWithAppliedLogs(t *testing.T) {
+	dataDir, err := ioutil.TempDir("", "casbin-hraft-dispatcher-")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dataDir)
+
+	_, leader, err := newNode(dataDir, "127.0.0.1:6910", "")
+	assert.NoError(t, err)
+	defer leader.Shutdown()
+
+	var nodeDir string
+	e, follower, err := newNode(dataDir, "127.0.0.1:6920", "127.0.0.1:6910", func(config *Config) {
+		nodeDir = config.DataDir
+	})
+	assert.NoError(t, err)
+	ctx := WithRequestID(context.Background(), "add-alice")
+	_, err = leader.AddPoliciesContext(ctx, "p", "p", [][]string{{"alice", "/", "GET"}})
+	assert.NoError(t, err)
+	err = leader.AddPolicies("p", "p", [][]string{{"bob", "/", "GET"}})
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return follower.Revision() == leader.Revision()
+	}, 5*time.Second, 50*time.Millisecond)
+	follower.Shutdown()
+
+	// raft replays the log into a new enforcer, the logs applied to the database are skipped.
+	e, follower, err = newNode(dataDir, "127.0.0.1:6920", "", func(config *Config) {
+		config.DataDir = nodeDir
+	})
+	assert.NoError(t, err)
+	defer follower.Shutdown()
+
+	ok, err := e.Enforce("alice", "/", "GET")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	err = leader.AddPolicies("p", "p", [][]string{{"carol", "/", "GET"}})
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		ok, err := e.Enforce("carol", "/", "GET")
+		return err == nil && ok
+	}, 5*time.Second, 50*time.Millisecond)
+	assert.Equal(t, leader.Revision(), follower.Revision())
+
+	// the request ID is still known after the restart.
+	resp, err := follower.AddPoliciesContext(ctx, "p", "p", [][]string{{"alice", "/", "GET"}})
+	assert.NoError(t, err)
+	assert.True(t, resp.Duplicate)
+	assert.Equal(t, leader.Revision(), follower.Revision())
+}
+
+func TestAdvertiseAddress(t *testing.T) {
+	dataDir, err := ioutil.TempDir("", "casbin-hraft-dispatcher-")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dataDir)
+
+	_, leader, err := newNode(dataDir, "0.0.0.0:6870", "", func(config *Config) {
+		config.AdvertiseAddress = "127.0.0.1:6870"
+		config.AllowLoopbackAdvertise = true
+	})
+	assert.NoError(t, err)
+	defer leader.Shutdown()
+	_, follower, err := newNode(dataDir, "0.0.0.0:6880", "127.0.0.1:6870", func(config *Config) {
+		config.AdvertiseAddress = "127.0.0.1:6880"
+		config.AllowLoopbackAdvertise = true
+	})
+	assert.NoError(t, err)
+	defer follower.Shutdown()
+
+	// the server ID defaults to the advertise address.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	members, err := follower.Members(ctx)
+	assert.NoError(t, err)
+	if assert.Len(t, members, 2) {
+		assert.Equal(t, "127.0.0.1:6870", members[0].Id)
+		assert.Equal(t, "127.0.0.1:6870", members[0].Address)
+		assert.True(t, members[0].Leader)
+		assert.Equal(t, "127.0.0.1:6880", members[1].Id)
+		assert.Equal(t, "127.0.0.1:6880", members[1].Address)
+		assert.Empty(t, members[1].Error)
+	}
+
+	// the write on the follower is redirected to the advertise address of the leader.
+	err = follower.AddPolicies("p", "p", [][]string{{"alice", "/", "GET"}})
+	assert.NoError(t, err)
+}
+
 func TestNewHRaftDispatcher(t *testing.T) {
 	_, err := NewHRaftDispatcher(&Config{
 		Enforcer:      &mocks.MockIDistributedEnforcer{},
@@ -553,4 +1038,54 @@ func TestNewHRaftDispatcher(t *testing.T) {
 		RaftConfig:    nil,
 	})
 	assert.EqualError(t, err, "cannot use unspecified IP 0.0.0.0")
+
+	_, err = NewHRaftDispatcher(&Config{
+		Enforcer:      &mocks.MockIDistributedEnforcer{},
+		ServerID:      "test",
+		DataDir:       "/tmp/hraft-dispatcher",
+		ListenAddress: "127.0.0.1:6780",
+		Role:          "observer",
+	})
+	assert.EqualError(t, err, `invalid role "observer", it must be voter or nonvoter`)
+
+	_, err = NewHRaftDispatcher(&Config{
+		Enforcer:      &mocks.MockIDistributedEnforcer{},
+		ServerID:      "test",
+		DataDir:       "/tmp/hraft-dispatcher",
+		ListenAddress: "127.0.0.1:6780",
+		Role:          http.RoleNonvoter,
+	})
+	assert.EqualError(t, err, "a nonvoter must join an existing cluster by JoinAddress or JoinAddresses")
+
+	for address, message := range map[string]string{
+		"0.0.0.0:6780":     "AdvertiseAddress 0.0.0.0:6780 is not routable",
+		"224.0.0.1:6780":   "AdvertiseAddress 224.0.0.1:6780 is not routable",
+		"169.254.0.1:6780": "AdvertiseAddress 169.254.0.1:6780 is not routable",
+		"[fe80::1]:6780":   "AdvertiseAddress [fe80::1]:6780 is not routable",
+		"[ff02::1]:6780":   "AdvertiseAddress [ff02::1]:6780 is not routable",
+		"127.0.0.1:6780":   "AdvertiseAddress 127.0.0.1:6780 is a loopback address, set AllowLoopbackAdvertise to use it",
+		"[::1]:6780":       "AdvertiseAddress [::1]:6780 is a loopback address, set AllowLoopbackAdvertise to use it",
+		":6780":            "host is omitted in AdvertiseAddress :6780",
+		"10.0.0.1:0":       "invalid port in AdvertiseAddress 10.0.0.1:0",
+		"10.0.0.1":         "invalid AdvertiseAddress 10.0.0.1: address 10.0.0.1: missing port in address",
+	} {
+		_, err = NewHRaftDispatcher(&Config{
+			Enforcer:         &mocks.MockIDistributedEnforcer{},
+			DataDir:          "/tmp/hraft-dispatcher",
+			ListenAddress:    "0.0.0.0:6780",
+			AdvertiseAddress: address,
+		})
+		assert.EqualError(t, err, message)
+	}
+
+	// the current node is not a join address of itself.
+	_, err = NewHRaftDispatcher(&Config{
+		Enforcer:      &mocks.MockIDistributedEnforcer{},
+		ServerID:      "test",
+		DataDir:       "/tmp/hraft-dispatcher",
+		ListenAddress: "127.0.0.1:6780",
+		JoinAddresses: []string{"127.0.0.1:6780"},
+		Role:          http.RoleNonvoter,
+	})
+	assert.EqualError(t, err, "a nonvoter must join an existing cluster by JoinAddress or JoinAddresses")
 }
@@ -0,0 +1,76 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+)
+
+const (
+	// ExpectedRevisionHeader is the request header that carries the revision of the policies expected by a write.
+	ExpectedRevisionHeader = "X-Expected-Revision"
+	// RequestIDHeader is the request header that carries the request ID of a write.
+	RequestIDHeader = "X-Request-Id"
+)
+
+type contextKey int
+
+const (
+	expectedRevisionKey contextKey = iota
+	requestIDKey
+)
+
+// WithExpectedRevision returns a copy of ctx, the writes made with it are applied only if
+// the revision of the policies equals the given revision, otherwise a conflict error is returned.
+func WithExpectedRevision(ctx context.Context, revision uint64) context.Context {
+	return context.WithValue(ctx, expectedRevisionKey, revision)
+}
+
+// ExpectedRevision returns the revision set by WithExpectedRevision.
+func ExpectedRevision(ctx context.Context) (uint64, bool) {
+	revision, ok := ctx.Value(expectedRevisionKey).(uint64)
+	return revision, ok
+}
+
+// WithRequestID returns a copy of ctx, the writes made with it carry the given request ID.
+// The cluster remembers the responses of the latest request IDs, a write retried with the same request ID
+// returns the response of the applied one instead of being applied again.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey, id)
+}
+
+// RequestID returns the request ID set by WithRequestID.
+func RequestID(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey).(string)
+	return id, ok && len(id) != 0
+}
+
+// withExpectedRevision sets the revision in the ExpectedRevisionHeader header to the context of the request.
+func withExpectedRevision(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		value := r.Header.Get(ExpectedRevisionHeader)
+		if len(value) == 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		revision, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, ErrorCodeBadRequest, err)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(WithExpectedRevision(r.Context(), revision)))
+	})
+}
+
+// withRequestID sets the request ID in the RequestIDHeader header to the context of the request.
+func withRequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if len(id) == 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(WithRequestID(r.Context(), id)))
+	})
+}
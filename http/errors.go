@@ -0,0 +1,94 @@
+package http
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Error codes carried by the body of a failed request.
+const (
+	// ErrorCodeBadRequest indicates the request cannot be parsed.
+	ErrorCodeBadRequest = "bad_request"
+	// ErrorCodeApplyFailed indicates the command has been committed, but it is rejected by the FSM.
+	ErrorCodeApplyFailed = "apply_failed"
+	// ErrorCodeConflict indicates the precondition of the write does not hold.
+	ErrorCodeConflict = "conflict"
+	// ErrorCodeTimeout indicates the request is not completed before its deadline.
+	ErrorCodeTimeout = "timeout"
+	// ErrorCodeUnavailable indicates the cluster cannot serve the request currently.
+	ErrorCodeUnavailable = "unavailable"
+	// ErrorCodeInternal indicates an unexpected error of the server.
+	ErrorCodeInternal = "internal"
+)
+
+// ApplyError is returned by Store when the FSM fails to apply a command.
+// The command is rejected on every node, so it is not necessary to retry it.
+type ApplyError struct {
+	Err error
+}
+
+// NewApplyError returns an ApplyError.
+func NewApplyError(err error) *ApplyError {
+	return &ApplyError{Err: err}
+}
+
+func (e *ApplyError) Error() string {
+	return fmt.Sprintf("failed to apply the command: %s", e.Err)
+}
+
+func (e *ApplyError) Unwrap() error {
+	return e.Err
+}
+
+// RevisionConflictError is returned by Store when the revision of the policies
+// is not the revision expected by the write.
+type RevisionConflictError struct {
+	Expected uint64
+	Actual   uint64
+}
+
+// NewRevisionConflictError returns a RevisionConflictError.
+func NewRevisionConflictError(expected, actual uint64) *RevisionConflictError {
+	return &RevisionConflictError{Expected: expected, Actual: actual}
+}
+
+func (e *RevisionConflictError) Error() string {
+	return fmt.Sprintf("the revision of the policies is %d, but %d is expected", e.Actual, e.Expected)
+}
+
+// Error is the structured body of a failed request,
+// and it is also the error returned by the Do*Request methods of Service.
+type Error struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int `json:"-"`
+	// Code is a machine-readable string identifying the error.
+	Code string `json:"code"`
+	// Message is a human-readable description of the error.
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+// writeError writes an Error with the given status code to the response.
+func writeError(w http.ResponseWriter, statusCode int, code string, err error) {
+	b, _ := jsoniter.Marshal(&Error{Code: code, Message: err.Error()})
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_, _ = w.Write(b)
+}
+
+// ReadError reads an Error from a failed response.
+func ReadError(resp *http.Response) error {
+	e := &Error{StatusCode: resp.StatusCode}
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil || jsoniter.Unmarshal(data, e) != nil || len(e.Code) == 0 {
+		e.Code = ErrorCodeUnavailable
+		e.Message = http.StatusText(resp.StatusCode)
+	}
+	return e
+}
@@ -5,10 +5,13 @@
 package mocks
 
 import (
+	context "context"
+	io "io"
 	reflect "reflect"
 
 	command "github.com/casbin/hraft-dispatcher/command"
 	gomock "github.com/golang/mock/gomock"
+	raft "github.com/hashicorp/raft"
 )
 
 // MockStore is a mock of Store interface.
@@ -35,45 +38,140 @@ func (m *MockStore) EXPECT() *MockStoreMockRecorder {
 }
 
 // AddPolicies mocks base method.
-func (m *MockStore) AddPolicies(request *command.AddPoliciesRequest) error {
+func (m *MockStore) AddPolicies(ctx context.Context, request *command.AddPoliciesRequest) (*command.ApplyResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddPolicies", request)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "AddPolicies", ctx, request)
+	ret0, _ := ret[0].(*command.ApplyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // AddPolicies indicates an expected call of AddPolicies.
-func (mr *MockStoreMockRecorder) AddPolicies(request interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) AddPolicies(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPolicies", reflect.TypeOf((*MockStore)(nil).AddPolicies), ctx, request)
+}
+
+// Barrier mocks base method.
+func (m *MockStore) Barrier(ctx context.Context) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Barrier", ctx)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Barrier indicates an expected call of Barrier.
+func (mr *MockStoreMockRecorder) Barrier(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Barrier", reflect.TypeOf((*MockStore)(nil).Barrier), ctx)
+}
+
+// CancelScheduledPolicies mocks base method.
+func (m *MockStore) CancelScheduledPolicies(ctx context.Context, request *command.CancelScheduledPoliciesRequest) (*command.ApplyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelScheduledPolicies", ctx, request)
+	ret0, _ := ret[0].(*command.ApplyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelScheduledPolicies indicates an expected call of CancelScheduledPolicies.
+func (mr *MockStoreMockRecorder) CancelScheduledPolicies(ctx, request interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPolicies", reflect.TypeOf((*MockStore)(nil).AddPolicies), request)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelScheduledPolicies", reflect.TypeOf((*MockStore)(nil).CancelScheduledPolicies), ctx, request)
 }
 
 // ClearPolicy mocks base method.
-func (m *MockStore) ClearPolicy() error {
+func (m *MockStore) ClearPolicy(ctx context.Context) (*command.ApplyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearPolicy", ctx)
+	ret0, _ := ret[0].(*command.ApplyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClearPolicy indicates an expected call of ClearPolicy.
+func (mr *MockStoreMockRecorder) ClearPolicy(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearPolicy", reflect.TypeOf((*MockStore)(nil).ClearPolicy), ctx)
+}
+
+// DemoteNode mocks base method.
+func (m *MockStore) DemoteNode(serverID string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ClearPolicy")
+	ret := m.ctrl.Call(m, "DemoteNode", serverID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// ClearPolicy indicates an expected call of ClearPolicy.
-func (mr *MockStoreMockRecorder) ClearPolicy() *gomock.Call {
+// DemoteNode indicates an expected call of DemoteNode.
+func (mr *MockStoreMockRecorder) DemoteNode(serverID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DemoteNode", reflect.TypeOf((*MockStore)(nil).DemoteNode), serverID)
+}
+
+// EnforceEx mocks base method.
+func (m *MockStore) EnforceEx(rvals ...interface{}) (bool, []string, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range rvals {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "EnforceEx", varargs...)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].([]string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// EnforceEx indicates an expected call of EnforceEx.
+func (mr *MockStoreMockRecorder) EnforceEx(rvals ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnforceEx", reflect.TypeOf((*MockStore)(nil).EnforceEx), rvals...)
+}
+
+// ForEachPolicy mocks base method.
+func (m *MockStore) ForEachPolicy(fn func(*command.Policy) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForEachPolicy", fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForEachPolicy indicates an expected call of ForEachPolicy.
+func (mr *MockStoreMockRecorder) ForEachPolicy(fn interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearPolicy", reflect.TypeOf((*MockStore)(nil).ClearPolicy))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForEachPolicy", reflect.TypeOf((*MockStore)(nil).ForEachPolicy), fn)
+}
+
+// ImportPolicies mocks base method.
+func (m *MockStore) ImportPolicies(ctx context.Context, request *command.ImportPoliciesRequest) (*command.ApplyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportPolicies", ctx, request)
+	ret0, _ := ret[0].(*command.ApplyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportPolicies indicates an expected call of ImportPolicies.
+func (mr *MockStoreMockRecorder) ImportPolicies(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportPolicies", reflect.TypeOf((*MockStore)(nil).ImportPolicies), ctx, request)
 }
 
 // JoinNode mocks base method.
-func (m *MockStore) JoinNode(serverID, address string) error {
+func (m *MockStore) JoinNode(serverID, address, role string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "JoinNode", serverID, address)
+	ret := m.ctrl.Call(m, "JoinNode", serverID, address, role)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // JoinNode indicates an expected call of JoinNode.
-func (mr *MockStoreMockRecorder) JoinNode(serverID, address interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) JoinNode(serverID, address, role interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JoinNode", reflect.TypeOf((*MockStore)(nil).JoinNode), serverID, address)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JoinNode", reflect.TypeOf((*MockStore)(nil).JoinNode), serverID, address, role)
 }
 
 // Leader mocks base method.
@@ -91,18 +189,77 @@ func (mr *MockStoreMockRecorder) Leader() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Leader", reflect.TypeOf((*MockStore)(nil).Leader))
 }
 
-// RemoveFilteredPolicy mocks base method.
-func (m *MockStore) RemoveFilteredPolicy(request *command.RemoveFilteredPolicyRequest) error {
+// Members mocks base method.
+func (m *MockStore) Members() ([]*command.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Members")
+	ret0, _ := ret[0].([]*command.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Members indicates an expected call of Members.
+func (mr *MockStoreMockRecorder) Members() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Members", reflect.TypeOf((*MockStore)(nil).Members))
+}
+
+// NodeStatus mocks base method.
+func (m *MockStore) NodeStatus() *command.NodeStatus {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RemoveFilteredPolicy", request)
+	ret := m.ctrl.Call(m, "NodeStatus")
+	ret0, _ := ret[0].(*command.NodeStatus)
+	return ret0
+}
+
+// NodeStatus indicates an expected call of NodeStatus.
+func (mr *MockStoreMockRecorder) NodeStatus() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NodeStatus", reflect.TypeOf((*MockStore)(nil).NodeStatus))
+}
+
+// PendingPolicies mocks base method.
+func (m *MockStore) PendingPolicies() ([]*command.PendingPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PendingPolicies")
+	ret0, _ := ret[0].([]*command.PendingPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PendingPolicies indicates an expected call of PendingPolicies.
+func (mr *MockStoreMockRecorder) PendingPolicies() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingPolicies", reflect.TypeOf((*MockStore)(nil).PendingPolicies))
+}
+
+// PromoteNode mocks base method.
+func (m *MockStore) PromoteNode(serverID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PromoteNode", serverID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// PromoteNode indicates an expected call of PromoteNode.
+func (mr *MockStoreMockRecorder) PromoteNode(serverID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PromoteNode", reflect.TypeOf((*MockStore)(nil).PromoteNode), serverID)
+}
+
+// RemoveFilteredPolicy mocks base method.
+func (m *MockStore) RemoveFilteredPolicy(ctx context.Context, request *command.RemoveFilteredPolicyRequest) (*command.ApplyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveFilteredPolicy", ctx, request)
+	ret0, _ := ret[0].(*command.ApplyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // RemoveFilteredPolicy indicates an expected call of RemoveFilteredPolicy.
-func (mr *MockStoreMockRecorder) RemoveFilteredPolicy(request interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) RemoveFilteredPolicy(ctx, request interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFilteredPolicy", reflect.TypeOf((*MockStore)(nil).RemoveFilteredPolicy), request)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFilteredPolicy", reflect.TypeOf((*MockStore)(nil).RemoveFilteredPolicy), ctx, request)
 }
 
 // RemoveNode mocks base method.
@@ -120,17 +277,78 @@ func (mr *MockStoreMockRecorder) RemoveNode(serverID interface{}) *gomock.Call {
 }
 
 // RemovePolicies mocks base method.
-func (m *MockStore) RemovePolicies(request *command.RemovePoliciesRequest) error {
+func (m *MockStore) RemovePolicies(ctx context.Context, request *command.RemovePoliciesRequest) (*command.ApplyResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RemovePolicies", request)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "RemovePolicies", ctx, request)
+	ret0, _ := ret[0].(*command.ApplyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // RemovePolicies indicates an expected call of RemovePolicies.
-func (mr *MockStoreMockRecorder) RemovePolicies(request interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) RemovePolicies(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePolicies", reflect.TypeOf((*MockStore)(nil).RemovePolicies), ctx, request)
+}
+
+// ReschedulePolicies mocks base method.
+func (m *MockStore) ReschedulePolicies(ctx context.Context, request *command.ReschedulePoliciesRequest) (*command.ApplyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReschedulePolicies", ctx, request)
+	ret0, _ := ret[0].(*command.ApplyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReschedulePolicies indicates an expected call of ReschedulePolicies.
+func (mr *MockStoreMockRecorder) ReschedulePolicies(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReschedulePolicies", reflect.TypeOf((*MockStore)(nil).ReschedulePolicies), ctx, request)
+}
+
+// Revision mocks base method.
+func (m *MockStore) Revision() uint64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revision")
+	ret0, _ := ret[0].(uint64)
+	return ret0
+}
+
+// Revision indicates an expected call of Revision.
+func (mr *MockStoreMockRecorder) Revision() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePolicies", reflect.TypeOf((*MockStore)(nil).RemovePolicies), request)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revision", reflect.TypeOf((*MockStore)(nil).Revision))
+}
+
+// SchedulePolicies mocks base method.
+func (m *MockStore) SchedulePolicies(ctx context.Context, request *command.SchedulePoliciesRequest) (*command.ApplyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SchedulePolicies", ctx, request)
+	ret0, _ := ret[0].(*command.ApplyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SchedulePolicies indicates an expected call of SchedulePolicies.
+func (mr *MockStoreMockRecorder) SchedulePolicies(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SchedulePolicies", reflect.TypeOf((*MockStore)(nil).SchedulePolicies), ctx, request)
+}
+
+// Snapshot mocks base method.
+func (m *MockStore) Snapshot() (*raft.SnapshotMeta, io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Snapshot")
+	ret0, _ := ret[0].(*raft.SnapshotMeta)
+	ret1, _ := ret[1].(io.ReadCloser)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Snapshot indicates an expected call of Snapshot.
+func (mr *MockStoreMockRecorder) Snapshot() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Snapshot", reflect.TypeOf((*MockStore)(nil).Snapshot))
 }
 
 // Stats mocks base method.
@@ -148,44 +366,90 @@ func (mr *MockStoreMockRecorder) Stats() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stats", reflect.TypeOf((*MockStore)(nil).Stats))
 }
 
-// UpdateFilteredPolicies mocks base method.
-func (m *MockStore) UpdateFilteredPolicies(request *command.UpdateFilteredPoliciesRequest) error {
+// Transaction mocks base method.
+func (m *MockStore) Transaction(ctx context.Context, request *command.TransactionRequest) (*command.ApplyResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateFilteredPolicies", request)
+	ret := m.ctrl.Call(m, "Transaction", ctx, request)
+	ret0, _ := ret[0].(*command.ApplyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Transaction indicates an expected call of Transaction.
+func (mr *MockStoreMockRecorder) Transaction(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transaction", reflect.TypeOf((*MockStore)(nil).Transaction), ctx, request)
+}
+
+// TransferLeadership mocks base method.
+func (m *MockStore) TransferLeadership(serverID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransferLeadership", serverID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// TransferLeadership indicates an expected call of TransferLeadership.
+func (mr *MockStoreMockRecorder) TransferLeadership(serverID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferLeadership", reflect.TypeOf((*MockStore)(nil).TransferLeadership), serverID)
+}
+
+// UpdateFilteredPolicies mocks base method.
+func (m *MockStore) UpdateFilteredPolicies(ctx context.Context, request *command.UpdateFilteredPoliciesRequest) (*command.ApplyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFilteredPolicies", ctx, request)
+	ret0, _ := ret[0].(*command.ApplyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // UpdateFilteredPolicies indicates an expected call of UpdateFilteredPolicies.
-func (mr *MockStoreMockRecorder) UpdateFilteredPolicies(request interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) UpdateFilteredPolicies(ctx, request interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFilteredPolicies", reflect.TypeOf((*MockStore)(nil).UpdateFilteredPolicies), request)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFilteredPolicies", reflect.TypeOf((*MockStore)(nil).UpdateFilteredPolicies), ctx, request)
 }
 
 // UpdatePolicies mocks base method.
-func (m *MockStore) UpdatePolicies(request *command.UpdatePoliciesRequest) error {
+func (m *MockStore) UpdatePolicies(ctx context.Context, request *command.UpdatePoliciesRequest) (*command.ApplyResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdatePolicies", request)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "UpdatePolicies", ctx, request)
+	ret0, _ := ret[0].(*command.ApplyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // UpdatePolicies indicates an expected call of UpdatePolicies.
-func (mr *MockStoreMockRecorder) UpdatePolicies(request interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) UpdatePolicies(ctx, request interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePolicies", reflect.TypeOf((*MockStore)(nil).UpdatePolicies), request)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePolicies", reflect.TypeOf((*MockStore)(nil).UpdatePolicies), ctx, request)
 }
 
 // UpdatePolicy mocks base method.
-func (m *MockStore) UpdatePolicy(request *command.UpdatePolicyRequest) error {
+func (m *MockStore) UpdatePolicy(ctx context.Context, request *command.UpdatePolicyRequest) (*command.ApplyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePolicy", ctx, request)
+	ret0, _ := ret[0].(*command.ApplyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePolicy indicates an expected call of UpdatePolicy.
+func (mr *MockStoreMockRecorder) UpdatePolicy(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePolicy", reflect.TypeOf((*MockStore)(nil).UpdatePolicy), ctx, request)
+}
+
+// WaitForAppliedIndex mocks base method.
+func (m *MockStore) WaitForAppliedIndex(ctx context.Context, index uint64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdatePolicy", request)
+	ret := m.ctrl.Call(m, "WaitForAppliedIndex", ctx, index)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdatePolicy indicates an expected call of UpdatePolicy.
-func (mr *MockStoreMockRecorder) UpdatePolicy(request interface{}) *gomock.Call {
+// WaitForAppliedIndex indicates an expected call of WaitForAppliedIndex.
+func (mr *MockStoreMockRecorder) WaitForAppliedIndex(ctx, index interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePolicy", reflect.TypeOf((*MockStore)(nil).UpdatePolicy), request)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForAppliedIndex", reflect.TypeOf((*MockStore)(nil).WaitForAppliedIndex), ctx, index)
 }
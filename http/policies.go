@@ -0,0 +1,241 @@
+package http
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"strconv"
+
+	"github.com/casbin/hraft-dispatcher/command"
+	jsoniter "github.com/json-iterator/go"
+)
+
+const (
+	// PolicyFormatCSV is the format of the Casbin policy file, each line is a policy type followed by a rule.
+	PolicyFormatCSV = "csv"
+	// PolicyFormatJSON is a JSON array of command.Policy.
+	PolicyFormatJSON = "json"
+)
+
+const (
+	// ConsistencyStale serves a read from the current node, it may not observe the latest writes.
+	ConsistencyStale = "stale"
+	// ConsistencyLeader serves a read from the leader without confirming its leadership,
+	// it observes the writes applied to the leader.
+	ConsistencyLeader = "leader"
+	// ConsistencyLinearizable serves a read after the current node applies all writes committed before it.
+	ConsistencyLinearizable = "linearizable"
+)
+
+// PolicyQuery selects the policies returned by the query routes.
+// The rules are matched like RemoveFilteredPolicy, an empty field value matches any value.
+type PolicyQuery struct {
+	// Sec is the section of the policies, such as p or g, an empty Sec matches any section.
+	Sec string
+	// PType is the policy type of the policies, an empty PType matches any policy type.
+	PType string
+	// FieldIndex is the index of the first field matched by FieldValues.
+	FieldIndex int
+	// FieldValues are the values of the fields from FieldIndex.
+	FieldValues []string
+	// Offset is the number of the matched policies skipped.
+	Offset int
+	// Limit is the maximum number of the returned policies, 0 means no limit.
+	Limit int
+	// Consistency is ConsistencyStale, ConsistencyLeader or ConsistencyLinearizable, ConsistencyStale by default.
+	Consistency string
+}
+
+// ParsePolicyQuery parses the query parameters sec, ptype, fieldIndex, fieldValues, offset, limit and consistency.
+// fieldValues may be repeated, one parameter per field.
+func ParsePolicyQuery(values url.Values) (*PolicyQuery, error) {
+	q := &PolicyQuery{
+		Sec:         values.Get("sec"),
+		PType:       values.Get("ptype"),
+		FieldValues: values["fieldValues"],
+		Consistency: values.Get("consistency"),
+	}
+
+	var err error
+	for name, value := range map[string]*int{"fieldIndex": &q.FieldIndex, "offset": &q.Offset, "limit": &q.Limit} {
+		s := values.Get(name)
+		if len(s) == 0 {
+			continue
+		}
+		*value, err = strconv.Atoi(s)
+		if err != nil || *value < 0 {
+			return nil, fmt.Errorf("invalid %s: %s", name, s)
+		}
+	}
+
+	switch q.Consistency {
+	case "":
+		q.Consistency = ConsistencyStale
+	case ConsistencyStale, ConsistencyLeader, ConsistencyLinearizable:
+	default:
+		return nil, fmt.Errorf("unknown consistency: %s", q.Consistency)
+	}
+	return q, nil
+}
+
+// Values encodes the query to the query parameters parsed by ParsePolicyQuery.
+func (q *PolicyQuery) Values() url.Values {
+	values := url.Values{}
+	if len(q.Sec) != 0 {
+		values.Set("sec", q.Sec)
+	}
+	if len(q.PType) != 0 {
+		values.Set("ptype", q.PType)
+	}
+	if q.FieldIndex != 0 {
+		values.Set("fieldIndex", strconv.Itoa(q.FieldIndex))
+	}
+	for _, value := range q.FieldValues {
+		values.Add("fieldValues", value)
+	}
+	if q.Offset != 0 {
+		values.Set("offset", strconv.Itoa(q.Offset))
+	}
+	if q.Limit != 0 {
+		values.Set("limit", strconv.Itoa(q.Limit))
+	}
+	if len(q.Consistency) != 0 {
+		values.Set("consistency", q.Consistency)
+	}
+	return values
+}
+
+// Match checks if the policy is selected by the query.
+func (q *PolicyQuery) Match(policy *command.Policy) bool {
+	if len(q.Sec) != 0 && q.Sec != policy.Sec {
+		return false
+	}
+	if len(q.PType) != 0 && q.PType != policy.PType {
+		return false
+	}
+	for i, value := range q.FieldValues {
+		if len(value) == 0 {
+			continue
+		}
+		if q.FieldIndex+i >= len(policy.Rule) || policy.Rule[q.FieldIndex+i] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// PolicyWriter writes the policies in a given format one by one.
+type PolicyWriter struct {
+	w      io.Writer
+	format string
+	csv    *csv.Writer
+	count  int
+}
+
+// NewPolicyWriter returns a PolicyWriter writing to w in the given format.
+func NewPolicyWriter(w io.Writer, format string) (*PolicyWriter, error) {
+	switch format {
+	case PolicyFormatCSV:
+		return &PolicyWriter{w: w, format: format, csv: csv.NewWriter(w)}, nil
+	case PolicyFormatJSON:
+		return &PolicyWriter{w: w, format: format}, nil
+	default:
+		return nil, fmt.Errorf("unknown policy format: %s", format)
+	}
+}
+
+// ContentType returns the media type of the format.
+func (p *PolicyWriter) ContentType() string {
+	if p.format == PolicyFormatJSON {
+		return "application/json"
+	}
+	return "text/csv"
+}
+
+// Write writes a policy.
+func (p *PolicyWriter) Write(policy *command.Policy) error {
+	p.count++
+	if p.format == PolicyFormatCSV {
+		return p.csv.Write(append([]string{policy.PType}, policy.Rule...))
+	}
+
+	b, err := jsoniter.Marshal(policy)
+	if err != nil {
+		return err
+	}
+	prefix := ","
+	if p.count == 1 {
+		prefix = "["
+	}
+	_, err = io.WriteString(p.w, prefix)
+	if err == nil {
+		_, err = p.w.Write(b)
+	}
+	return err
+}
+
+// Close writes the end of the policies, it does not close the underlying writer.
+func (p *PolicyWriter) Close() error {
+	if p.format == PolicyFormatCSV {
+		p.csv.Flush()
+		return p.csv.Error()
+	}
+
+	suffix := "]\n"
+	if p.count == 0 {
+		suffix = "[]\n"
+	}
+	_, err := io.WriteString(p.w, suffix)
+	return err
+}
+
+// ReadPolicies reads the policies written by PolicyWriter in the given format.
+// In the CSV format, the section of a policy is the first letter of its policy type.
+func ReadPolicies(r io.Reader, format string) ([]*command.Policy, error) {
+	switch format {
+	case PolicyFormatCSV:
+		reader := csv.NewReader(r)
+		reader.FieldsPerRecord = -1
+		reader.TrimLeadingSpace = true
+		reader.Comment = '#'
+
+		var policies []*command.Policy
+		for {
+			record, err := reader.Read()
+			if err == io.EOF {
+				return policies, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			if len(record) < 2 || len(record[0]) == 0 {
+				return nil, fmt.Errorf("invalid policy: %v", record)
+			}
+			policies = append(policies, &command.Policy{
+				Sec:   record[0][:1],
+				PType: record[0],
+				Rule:  record[1:],
+			})
+		}
+	case PolicyFormatJSON:
+		data, err := ioutil.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+		var policies []*command.Policy
+		err = jsoniter.Unmarshal(data, &policies)
+		if err != nil {
+			return nil, err
+		}
+		for _, policy := range policies {
+			if len(policy.GetSec()) == 0 || len(policy.GetPType()) == 0 || len(policy.GetRule()) == 0 {
+				return nil, fmt.Errorf("invalid policy: %s", policy)
+			}
+		}
+		return policies, nil
+	default:
+		return nil, fmt.Errorf("unknown policy format: %s", format)
+	}
+}
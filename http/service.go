@@ -4,12 +4,16 @@ import (
 	"bytes"
 	"context"
 	"crypto/tls"
+	stderrors "errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net"
 	"net/http"
 	"net/http/pprof"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"golang.org/x/net/http2"
@@ -18,6 +22,7 @@ import (
 
 	"github.com/hashicorp/go-multierror"
 
+	"github.com/cenkalti/backoff/v4"
 	"github.com/go-chi/chi"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/pkg/errors"
@@ -29,30 +34,97 @@ import (
 
 //go:generate mockgen -destination ./mocks/mock_store.go -package mocks -source service.go
 
+const (
+	// RaftIndexHeader is the response header that carries the raft log index of an applied write.
+	RaftIndexHeader = "X-Raft-Index"
+	// RequestTimeoutHeader is the request header that carries the time left before the deadline of the caller,
+	// the server bounds the handling of the request by it.
+	RequestTimeoutHeader = "X-Request-Timeout"
+)
+
+const (
+	// RoleVoter is a node that votes in elections and counts towards the quorum.
+	RoleVoter = "voter"
+	// RoleNonvoter is a node that receives the raft log but does not vote,
+	// it serves reads without increasing the quorum size.
+	RoleNonvoter = "nonvoter"
+)
+
+// defaultRequestTimeout is used by the Do*Request methods if the context has no deadline.
+const defaultRequestTimeout = 10 * time.Second
+
+// memberStatusTimeout bounds the request to gather the health of a member.
+const memberStatusTimeout = 2 * time.Second
+
 // Store provides an interface that can be implemented by raft.
 type Store interface {
 	// AddPolicies adds a set of rules to the current policy.
-	AddPolicies(request *command.AddPoliciesRequest) error
+	AddPolicies(ctx context.Context, request *command.AddPoliciesRequest) (*command.ApplyResponse, error)
 	// RemovePolicies removes a set of rules from the current policy.
-	RemovePolicies(request *command.RemovePoliciesRequest) error
+	RemovePolicies(ctx context.Context, request *command.RemovePoliciesRequest) (*command.ApplyResponse, error)
 	// RemoveFilteredPolicy removes a set of rules that match a pattern from the current policy.
-	RemoveFilteredPolicy(request *command.RemoveFilteredPolicyRequest) error
+	RemoveFilteredPolicy(ctx context.Context, request *command.RemoveFilteredPolicyRequest) (*command.ApplyResponse, error)
 	// UpdatePolicy updates a rule of policy.
-	UpdatePolicy(request *command.UpdatePolicyRequest) error
+	UpdatePolicy(ctx context.Context, request *command.UpdatePolicyRequest) (*command.ApplyResponse, error)
 	// UpdatePolicies updates a set of rules of policy.
-	UpdatePolicies(request *command.UpdatePoliciesRequest) error
+	UpdatePolicies(ctx context.Context, request *command.UpdatePoliciesRequest) (*command.ApplyResponse, error)
 	// UpdateFilteredPolicies updates a set of rules of policy.
-	UpdateFilteredPolicies(request *command.UpdateFilteredPoliciesRequest) error
+	UpdateFilteredPolicies(ctx context.Context, request *command.UpdateFilteredPoliciesRequest) (*command.ApplyResponse, error)
 	// ClearPolicy clears all policies.
-	ClearPolicy() error
-
-	// JoinNode joins a node with a given serverID and network address to cluster.
-	JoinNode(serverID string, address string) error
+	ClearPolicy(ctx context.Context) (*command.ApplyResponse, error)
+	// Transaction applies a set of operations atomically.
+	Transaction(ctx context.Context, request *command.TransactionRequest) (*command.ApplyResponse, error)
+	// Revision returns the revision of the policies applied to the current node.
+	Revision() uint64
+
+	// SchedulePolicies adds a set of rules to be activated at a given time.
+	SchedulePolicies(ctx context.Context, request *command.SchedulePoliciesRequest) (*command.ApplyResponse, error)
+	// ReschedulePolicies changes the activation time of a set of pending rules.
+	ReschedulePolicies(ctx context.Context, request *command.ReschedulePoliciesRequest) (*command.ApplyResponse, error)
+	// CancelScheduledPolicies removes a set of pending rules.
+	CancelScheduledPolicies(ctx context.Context, request *command.CancelScheduledPoliciesRequest) (*command.ApplyResponse, error)
+	// PendingPolicies returns the rules waiting to be activated on the current node.
+	PendingPolicies() ([]*command.PendingPolicy, error)
+
+	// ForEachPolicy calls fn for each rule applied to the current node.
+	ForEachPolicy(fn func(policy *command.Policy) error) error
+	// ImportPolicies replaces or merges the policies in a single command.
+	ImportPolicies(ctx context.Context, request *command.ImportPoliciesRequest) (*command.ApplyResponse, error)
+
+	// EnforceEx decides whether a request is allowed by the enforcer of the current node,
+	// the explanation is the rule that decides the request.
+	EnforceEx(rvals ...interface{}) (bool, []string, error)
+
+	// JoinNode joins a node with a given serverID and network address to cluster,
+	// the role is RoleVoter or RoleNonvoter.
+	JoinNode(serverID string, address string, role string) error
 	// RemoveNode removes a node with a given serverID from cluster.
 	RemoveNode(serverID string) error
+	// PromoteNode makes a nonvoter with a given serverID a voter.
+	PromoteNode(serverID string) error
+	// DemoteNode makes a voter with a given serverID a nonvoter.
+	DemoteNode(serverID string) error
+	// Members returns the servers in the raft configuration, the health of the servers is not set.
+	Members() ([]*command.Member, error)
+	// NodeStatus returns the health of the current node.
+	NodeStatus() *command.NodeStatus
+	// TransferLeadership transfers the leadership to the server with the given ID,
+	// or to the most up-to-date server if the ID is empty.
+	TransferLeadership(serverID string) error
 	// Leader checks if it is a leader and returns network address.
 	Leader() (bool, string)
 
+	// Barrier confirms the leadership and returns the index applied to the FSM
+	// after all preceding operations.
+	// The deadline of ctx bounds the time to wait for the barrier.
+	Barrier(ctx context.Context) (uint64, error)
+	// WaitForAppliedIndex blocks until the FSM has applied the given index.
+	WaitForAppliedIndex(ctx context.Context, index uint64) error
+
+	// Snapshot takes a snapshot of the FSM and opens it,
+	// the latest snapshot is opened if nothing is applied since it.
+	Snapshot() (*raft.SnapshotMeta, io.ReadCloser, error)
+
 	// Stats returns stats.
 	Stats() (map[string]interface{}, error)
 }
@@ -77,9 +149,7 @@ func NewService(logger *zap.Logger, ln net.Listener, tlsConfig *tls.Config, stor
 		return nil, errors.New("store is not provided")
 	}
 
-	httpClient := &http.Client{
-		Timeout: 10 * time.Second,
-	}
+	httpClient := &http.Client{}
 
 	if tlsConfig != nil {
 		// TODO: using http2.Transport always return unexpected eof on cmux.
@@ -98,14 +168,45 @@ func NewService(logger *zap.Logger, ln net.Listener, tlsConfig *tls.Config, stor
 
 	r := chi.NewRouter()
 	r.Route("/policies", func(r chi.Router) {
+		r.Use(withRequestTimeout, withExpectedRevision, withRequestID)
+		r.Get("/", s.handleListPolicies)
+		r.Get("/grouping", s.handleListGroupingPolicies)
+		r.Get("/revision", s.handleRevision)
 		r.Put("/add", s.handleAddPolicy)
 		r.Put("/update", s.handleUpdatePolicy)
 		r.Put("/remove", s.handleRemovePolicy)
+		r.Put("/txn", s.handleTransaction)
+		r.Get("/pending", s.handlePendingPolicies)
+		r.Put("/pending/add", s.handleSchedulePolicies)
+		r.Put("/pending/reschedule", s.handleReschedulePolicies)
+		r.Put("/pending/cancel", s.handleCancelScheduledPolicies)
+		r.Get("/export", s.handleExportPolicies)
+		r.Post("/import", s.handleImportPolicies)
+	})
+	r.Route("/enforce", func(r chi.Router) {
+		r.Use(withRequestTimeout)
+		r.Post("/", s.handleEnforce)
+		r.Post("/batch", s.handleBatchEnforce)
 	})
 	r.Route("/nodes", func(r chi.Router) {
+		r.Get("/", s.handleListNodes)
+		r.Get("/status", s.handleNodeStatus)
 		r.Put("/join", s.handleJoinNode)
 		r.Put("/remove", s.handleRemoveNode)
+		r.Put("/promote", s.handlePromoteNode)
+		r.Put("/demote", s.handleDemoteNode)
+		r.Put("/transfer-leadership", s.handleTransferLeadership)
+	})
+	r.Route("/reads", func(r chi.Router) {
+		r.Use(withRequestTimeout)
+		r.Get("/barrier", s.handleBarrier)
+	})
+	r.Route("/snapshot", func(r chi.Router) {
+		r.Post("/", s.handleTakeSnapshot)
+		r.Get("/", s.handleDownloadSnapshot)
 	})
+	r.Get("/stats", s.handleStats)
+	r.Get("/health", s.handleHealth)
 
 	// add pprof
 	r.HandleFunc("/debug/pprof/", pprof.Index)
@@ -193,45 +294,84 @@ func (s *Service) GetScheme() string {
 
 // handleStoreResponse checks the error returned by store.
 // If the error is nil, the server returns http.StatusOK.
-// If the error is raft.ErrNotLeader, the server forward the request to the leader node,
+// If the error is or wraps raft.ErrNotLeader, the server forward the request to the leader node.
+// If the error is an ApplyError, the server returns http.StatusUnprocessableEntity.
+// If the error is a RevisionConflictError, the server returns http.StatusConflict.
+// If the request times out, the server returns http.StatusGatewayTimeout,
 // otherwise the server returns http.StatusServiceUnavailable.
+// The body of a failed response is an Error encoded in JSON.
 func (s *Service) handleStoreResponse(err error, w http.ResponseWriter, r *http.Request) {
 	if err == nil {
 		w.WriteHeader(http.StatusOK)
 		return
 	}
-	if err == raft.ErrNotLeader {
+
+	if stderrors.Is(err, raft.ErrNotLeader) {
 		isLeader, leaderAddr := s.store.Leader()
 		if !isLeader {
 			if len(leaderAddr) == 0 {
 				s.logger.Error("failed to get the leader address")
-				w.WriteHeader(http.StatusServiceUnavailable)
+				writeError(w, http.StatusServiceUnavailable, ErrorCodeUnavailable, err)
 				return
 			}
 			redirectURL := s.getRedirectURL(r, leaderAddr)
 			http.Redirect(w, r, redirectURL, http.StatusTemporaryRedirect)
 			return
 		}
-	} else {
-		http.Error(w, err.Error(), http.StatusServiceUnavailable)
 	}
+
+	if _, ok := err.(*ApplyError); ok {
+		writeError(w, http.StatusUnprocessableEntity, ErrorCodeApplyFailed, err)
+		return
+	}
+
+	if _, ok := err.(*RevisionConflictError); ok {
+		writeError(w, http.StatusConflict, ErrorCodeConflict, err)
+		return
+	}
+
+	if err == context.DeadlineExceeded || err == raft.ErrEnqueueTimeout {
+		writeError(w, http.StatusGatewayTimeout, ErrorCodeTimeout, err)
+		return
+	}
+
+	writeError(w, http.StatusServiceUnavailable, ErrorCodeUnavailable, err)
+}
+
+// handleApplyResponse writes the response of a write applied by store.
+// If the error is nil, the server returns the applied log index in the RaftIndexHeader header and the body,
+// otherwise the error is handled by handleStoreResponse.
+func (s *Service) handleApplyResponse(resp *command.ApplyResponse, err error, w http.ResponseWriter, r *http.Request) {
+	if err != nil {
+		s.handleStoreResponse(err, w, r)
+		return
+	}
+
+	b, err := jsoniter.Marshal(resp)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, ErrorCodeInternal, err)
+		return
+	}
+	w.Header().Set(RaftIndexHeader, strconv.FormatUint(resp.Index, 10))
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(b)
 }
 
 // handleAddPolicy handles the request to add a set of rules.
 func (s *Service) handleAddPolicy(w http.ResponseWriter, r *http.Request) {
 	data, err := ioutil.ReadAll(r.Body)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, ErrorCodeBadRequest, err)
 		return
 	}
 	var cmd command.AddPoliciesRequest
 	err = jsoniter.Unmarshal(data, &cmd)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, ErrorCodeBadRequest, err)
 		return
 	}
-	err = s.store.AddPolicies(&cmd)
-	s.handleStoreResponse(err, w, r)
+	resp, err := s.store.AddPolicies(r.Context(), &cmd)
+	s.handleApplyResponse(resp, err, w, r)
 }
 
 // handleRemovePolicy handles the request to remove a set of rules.
@@ -239,38 +379,38 @@ func (s *Service) handleRemovePolicy(w http.ResponseWriter, r *http.Request) {
 	removeType := r.URL.Query().Get("type")
 	switch removeType {
 	case "all":
-		err := s.store.ClearPolicy()
-		s.handleStoreResponse(err, w, r)
+		resp, err := s.store.ClearPolicy(r.Context())
+		s.handleApplyResponse(resp, err, w, r)
 	case "filtered":
 		data, err := ioutil.ReadAll(r.Body)
 		if err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			writeError(w, http.StatusBadRequest, ErrorCodeBadRequest, err)
 			return
 		}
 		var cmd command.RemoveFilteredPolicyRequest
 		err = jsoniter.Unmarshal(data, &cmd)
 		if err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			writeError(w, http.StatusBadRequest, ErrorCodeBadRequest, err)
 			return
 		}
-		err = s.store.RemoveFilteredPolicy(&cmd)
-		s.handleStoreResponse(err, w, r)
+		resp, err := s.store.RemoveFilteredPolicy(r.Context(), &cmd)
+		s.handleApplyResponse(resp, err, w, r)
 	case "":
 		data, err := ioutil.ReadAll(r.Body)
 		if err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			writeError(w, http.StatusBadRequest, ErrorCodeBadRequest, err)
 			return
 		}
 		var cmd command.RemovePoliciesRequest
 		err = jsoniter.Unmarshal(data, &cmd)
 		if err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			writeError(w, http.StatusBadRequest, ErrorCodeBadRequest, err)
 			return
 		}
-		err = s.store.RemovePolicies(&cmd)
-		s.handleStoreResponse(err, w, r)
+		resp, err := s.store.RemovePolicies(r.Context(), &cmd)
+		s.handleApplyResponse(resp, err, w, r)
 	default:
-		w.WriteHeader(http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, ErrorCodeBadRequest, fmt.Errorf("unknown remove type: %s", removeType))
 	}
 }
 
@@ -281,174 +421,770 @@ func (s *Service) handleUpdatePolicy(w http.ResponseWriter, r *http.Request) {
 	case "batch":
 		data, err := ioutil.ReadAll(r.Body)
 		if err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			writeError(w, http.StatusBadRequest, ErrorCodeBadRequest, err)
 			return
 		}
 		var cmd command.UpdatePoliciesRequest
 		err = jsoniter.Unmarshal(data, &cmd)
 		if err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			writeError(w, http.StatusBadRequest, ErrorCodeBadRequest, err)
 			return
 		}
-		err = s.store.UpdatePolicies(&cmd)
-		s.handleStoreResponse(err, w, r)
+		resp, err := s.store.UpdatePolicies(r.Context(), &cmd)
+		s.handleApplyResponse(resp, err, w, r)
 	case "filtered":
 		data, err := ioutil.ReadAll(r.Body)
 		if err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			writeError(w, http.StatusBadRequest, ErrorCodeBadRequest, err)
 			return
 		}
 		var cmd command.UpdateFilteredPoliciesRequest
 		err = jsoniter.Unmarshal(data, &cmd)
 		if err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			writeError(w, http.StatusBadRequest, ErrorCodeBadRequest, err)
 			return
 		}
-		err = s.store.UpdateFilteredPolicies(&cmd)
-		s.handleStoreResponse(err, w, r)
+		resp, err := s.store.UpdateFilteredPolicies(r.Context(), &cmd)
+		s.handleApplyResponse(resp, err, w, r)
 	case "":
 		data, err := ioutil.ReadAll(r.Body)
 		if err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			writeError(w, http.StatusBadRequest, ErrorCodeBadRequest, err)
 			return
 		}
 		var cmd command.UpdatePolicyRequest
 		err = jsoniter.Unmarshal(data, &cmd)
 		if err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			writeError(w, http.StatusBadRequest, ErrorCodeBadRequest, err)
 			return
 		}
-		err = s.store.UpdatePolicy(&cmd)
+		resp, err := s.store.UpdatePolicy(r.Context(), &cmd)
+		s.handleApplyResponse(resp, err, w, r)
+	default:
+		writeError(w, http.StatusBadRequest, ErrorCodeBadRequest, fmt.Errorf("unknown update type: %s", updateType))
+	}
+}
+
+// handleTransaction handles the request to apply a set of operations atomically.
+func (s *Service) handleTransaction(w http.ResponseWriter, r *http.Request) {
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, ErrorCodeBadRequest, err)
+		return
+	}
+	var cmd command.TransactionRequest
+	err = jsoniter.Unmarshal(data, &cmd)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, ErrorCodeBadRequest, err)
+		return
+	}
+	resp, err := s.store.Transaction(r.Context(), &cmd)
+	s.handleApplyResponse(resp, err, w, r)
+}
+
+// handleSchedulePolicies handles the request to add a set of rules to be activated at a given time.
+func (s *Service) handleSchedulePolicies(w http.ResponseWriter, r *http.Request) {
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, ErrorCodeBadRequest, err)
+		return
+	}
+	var cmd command.SchedulePoliciesRequest
+	err = jsoniter.Unmarshal(data, &cmd)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, ErrorCodeBadRequest, err)
+		return
+	}
+	resp, err := s.store.SchedulePolicies(r.Context(), &cmd)
+	s.handleApplyResponse(resp, err, w, r)
+}
+
+// handleReschedulePolicies handles the request to change the activation time of a set of pending rules.
+func (s *Service) handleReschedulePolicies(w http.ResponseWriter, r *http.Request) {
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, ErrorCodeBadRequest, err)
+		return
+	}
+	var cmd command.ReschedulePoliciesRequest
+	err = jsoniter.Unmarshal(data, &cmd)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, ErrorCodeBadRequest, err)
+		return
+	}
+	resp, err := s.store.ReschedulePolicies(r.Context(), &cmd)
+	s.handleApplyResponse(resp, err, w, r)
+}
+
+// handleCancelScheduledPolicies handles the request to remove a set of pending rules.
+func (s *Service) handleCancelScheduledPolicies(w http.ResponseWriter, r *http.Request) {
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, ErrorCodeBadRequest, err)
+		return
+	}
+	var cmd command.CancelScheduledPoliciesRequest
+	err = jsoniter.Unmarshal(data, &cmd)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, ErrorCodeBadRequest, err)
+		return
+	}
+	resp, err := s.store.CancelScheduledPolicies(r.Context(), &cmd)
+	s.handleApplyResponse(resp, err, w, r)
+}
+
+// handlePendingPolicies handles the request to list the rules waiting to be activated on the current node.
+func (s *Service) handlePendingPolicies(w http.ResponseWriter, r *http.Request) {
+	policies, err := s.store.PendingPolicies()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, ErrorCodeInternal, err)
+		return
+	}
+
+	b, err := jsoniter.Marshal(&command.PendingPoliciesResponse{Policies: policies})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, ErrorCodeInternal, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(b)
+}
+
+// handleExportPolicies handles the request to export the rules applied to the current node.
+// The format query parameter is csv or json, csv is used by default.
+func (s *Service) handleExportPolicies(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if len(format) == 0 {
+		format = PolicyFormatCSV
+	}
+	pw, err := NewPolicyWriter(w, format)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, ErrorCodeBadRequest, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", pw.ContentType())
+	err = s.store.ForEachPolicy(pw.Write)
+	if err == nil {
+		err = pw.Close()
+	}
+	if err != nil {
+		// the status has been sent, the client gets a truncated body.
+		s.logger.Error("failed to export the policies", zap.Error(err))
+	}
+}
+
+// handleImportPolicies handles the request to import the rules in the body.
+// The format query parameter is csv or json, csv is used by default.
+// The mode query parameter is merge or replace, merge adds the rules to the current ones,
+// replace clears the current rules first. merge is used by default.
+func (s *Service) handleImportPolicies(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if len(format) == 0 {
+		format = PolicyFormatCSV
+	}
+
+	var replace bool
+	switch mode := r.URL.Query().Get("mode"); mode {
+	case "", "merge":
+	case "replace":
+		replace = true
+	default:
+		writeError(w, http.StatusBadRequest, ErrorCodeBadRequest, fmt.Errorf("unknown import mode: %s", mode))
+		return
+	}
+
+	policies, err := ReadPolicies(r.Body, format)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, ErrorCodeBadRequest, err)
+		return
+	}
+	resp, err := s.store.ImportPolicies(r.Context(), &command.ImportPoliciesRequest{Replace: replace, Policies: policies})
+	s.handleApplyResponse(resp, err, w, r)
+}
+
+// handleListPolicies handles the request to list the rules selected by the PolicyQuery in the query parameters.
+func (s *Service) handleListPolicies(w http.ResponseWriter, r *http.Request) {
+	query, err := ParsePolicyQuery(r.URL.Query())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, ErrorCodeBadRequest, err)
+		return
+	}
+	s.listPolicies(query, w, r)
+}
+
+// handleListGroupingPolicies handles the request to list the grouping rules, it is the same as
+// handleListPolicies with the section g.
+func (s *Service) handleListGroupingPolicies(w http.ResponseWriter, r *http.Request) {
+	query, err := ParsePolicyQuery(r.URL.Query())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, ErrorCodeBadRequest, err)
+		return
+	}
+	query.Sec = "g"
+	s.listPolicies(query, w, r)
+}
+
+// listPolicies writes the rules in casbin.db selected by the query,
+// once the current node meets the consistency level of the query.
+func (s *Service) listPolicies(query *PolicyQuery, w http.ResponseWriter, r *http.Request) {
+	err := s.waitForConsistency(r.Context(), query.Consistency)
+	if err != nil {
+		s.handleStoreResponse(err, w, r)
+		return
+	}
+
+	resp := &command.ListPoliciesResponse{Revision: s.store.Revision()}
+	err = s.store.ForEachPolicy(func(policy *command.Policy) error {
+		if !query.Match(policy) {
+			return nil
+		}
+		resp.Total++
+		if resp.Total > int64(query.Offset) && (query.Limit == 0 || len(resp.Policies) < query.Limit) {
+			resp.Policies = append(resp.Policies, policy)
+		}
+		return nil
+	})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, ErrorCodeInternal, err)
+		return
+	}
+
+	b, err := jsoniter.Marshal(resp)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, ErrorCodeInternal, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(b)
+}
+
+// waitForConsistency blocks until the current node can serve a read with the given consistency level.
+// raft.ErrNotLeader is returned if the level requires the leader.
+func (s *Service) waitForConsistency(ctx context.Context, consistency string) error {
+	switch consistency {
+	case ConsistencyLeader:
+		if isLeader, _ := s.store.Leader(); !isLeader {
+			return raft.ErrNotLeader
+		}
+		return nil
+	case ConsistencyLinearizable:
+		var index uint64
+		var err error
+		if isLeader, _ := s.store.Leader(); isLeader {
+			index, err = s.store.Barrier(ctx)
+		} else {
+			index, err = s.DoBarrierRequest(ctx)
+		}
+		if err != nil {
+			return err
+		}
+		return s.store.WaitForAppliedIndex(ctx, index)
+	default:
+		return nil
+	}
+}
+
+// handleEnforce handles the request to decide whether a request is allowed.
+// The consistency query parameter is the same as the one of handleListPolicies.
+func (s *Service) handleEnforce(w http.ResponseWriter, r *http.Request) {
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, ErrorCodeBadRequest, err)
+		return
+	}
+	var cmd command.EnforceRequest
+	err = jsoniter.Unmarshal(data, &cmd)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, ErrorCodeBadRequest, err)
+		return
+	}
+
+	s.enforce([]*command.EnforceRequest{&cmd}, w, r, func(responses []*command.EnforceResponse) interface{} {
+		return responses[0]
+	})
+}
+
+// handleBatchEnforce handles the request to decide whether a set of requests are allowed.
+func (s *Service) handleBatchEnforce(w http.ResponseWriter, r *http.Request) {
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, ErrorCodeBadRequest, err)
+		return
+	}
+	var cmd command.BatchEnforceRequest
+	err = jsoniter.Unmarshal(data, &cmd)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, ErrorCodeBadRequest, err)
+		return
+	}
+
+	s.enforce(cmd.Requests, w, r, func(responses []*command.EnforceResponse) interface{} {
+		return &command.BatchEnforceResponse{Responses: responses}
+	})
+}
+
+// enforce decides the requests once the current node meets the consistency level in the query parameters,
+// and writes the responses wrapped by the given function.
+func (s *Service) enforce(requests []*command.EnforceRequest, w http.ResponseWriter, r *http.Request, wrap func([]*command.EnforceResponse) interface{}) {
+	consistency := r.URL.Query().Get("consistency")
+	switch consistency {
+	case "", ConsistencyStale, ConsistencyLeader, ConsistencyLinearizable:
+	default:
+		writeError(w, http.StatusBadRequest, ErrorCodeBadRequest, fmt.Errorf("unknown consistency: %s", consistency))
+		return
+	}
+	err := s.waitForConsistency(r.Context(), consistency)
+	if err != nil {
 		s.handleStoreResponse(err, w, r)
+		return
+	}
+
+	responses := make([]*command.EnforceResponse, 0, len(requests))
+	for _, request := range requests {
+		rvals := make([]interface{}, 0, len(request.GetValues()))
+		for _, value := range request.GetValues() {
+			rvals = append(rvals, value)
+		}
+		allowed, explanation, err := s.store.EnforceEx(rvals...)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, ErrorCodeBadRequest, err)
+			return
+		}
+		responses = append(responses, &command.EnforceResponse{Allowed: allowed, Explanation: explanation})
+	}
+
+	b, err := jsoniter.Marshal(wrap(responses))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, ErrorCodeInternal, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(b)
+}
+
+// handleRevision handles the request to get the revision of the policies applied to the current node.
+func (s *Service) handleRevision(w http.ResponseWriter, r *http.Request) {
+	b, err := jsoniter.Marshal(&command.RevisionResponse{Revision: s.store.Revision()})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, ErrorCodeInternal, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(b)
+}
+
+// ParseRole checks the role of a node, an empty role is RoleVoter.
+func ParseRole(role string) (string, error) {
+	switch role {
+	case "", RoleVoter:
+		return RoleVoter, nil
+	case RoleNonvoter:
+		return RoleNonvoter, nil
 	default:
-		w.WriteHeader(http.StatusBadRequest)
+		return "", fmt.Errorf("invalid role %q, it must be %s or %s", role, RoleVoter, RoleNonvoter)
 	}
 }
 
 func (s *Service) handleJoinNode(w http.ResponseWriter, r *http.Request) {
 	data, err := ioutil.ReadAll(r.Body)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, ErrorCodeBadRequest, err)
 		return
 	}
 	var cmd command.AddNodeRequest
 	err = jsoniter.Unmarshal(data, &cmd)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, ErrorCodeBadRequest, err)
+		return
+	}
+	role, err := ParseRole(cmd.Role)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, ErrorCodeBadRequest, err)
 		return
 	}
-	err = s.store.JoinNode(cmd.Id, cmd.Address)
+	err = s.store.JoinNode(cmd.Id, cmd.Address, role)
 	s.handleStoreResponse(err, w, r)
 }
 
 func (s *Service) handleRemoveNode(w http.ResponseWriter, r *http.Request) {
 	data, err := ioutil.ReadAll(r.Body)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, ErrorCodeBadRequest, err)
 		return
 	}
 	var cmd command.RemoveNodeRequest
 	err = jsoniter.Unmarshal(data, &cmd)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, ErrorCodeBadRequest, err)
 		return
 	}
 	err = s.store.RemoveNode(cmd.Id)
 	s.handleStoreResponse(err, w, r)
 }
 
-func (s *Service) Addr() string {
-	return s.ln.Addr().String()
+// handleListNodes returns the members of the cluster with their health. It is redirected to the leader,
+// the lag of a member is measured against the log of the leader.
+func (s *Service) handleListNodes(w http.ResponseWriter, r *http.Request) {
+	isLeader, _ := s.store.Leader()
+	if !isLeader {
+		s.handleStoreResponse(raft.ErrNotLeader, w, r)
+		return
+	}
+	members, err := s.store.Members()
+	if err != nil {
+		s.handleStoreResponse(err, w, r)
+		return
+	}
+
+	leaderStatus := s.store.NodeStatus()
+	var wg sync.WaitGroup
+	for _, member := range members {
+		if member.Id == leaderStatus.Id {
+			setMemberStatus(member, leaderStatus, leaderStatus.LastIndex)
+			continue
+		}
+		wg.Add(1)
+		go func(member *command.Member) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(r.Context(), memberStatusTimeout)
+			defer cancel()
+			status, err := s.doNodeStatusRequest(ctx, member.Address)
+			if err != nil {
+				member.Error = err.Error()
+				return
+			}
+			setMemberStatus(member, status, leaderStatus.LastIndex)
+		}(member)
+	}
+	wg.Wait()
+
+	b, err := jsoniter.Marshal(&command.ListNodesResponse{
+		Members:   members,
+		LastIndex: leaderStatus.LastIndex,
+	})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, ErrorCodeInternal, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(b)
 }
 
-func (s *Service) DoAddPolicyRequest(request *command.AddPoliciesRequest) error {
-	b, err := jsoniter.Marshal(request)
+// setMemberStatus sets the health of a member by the status it reports.
+func setMemberStatus(member *command.Member, status *command.NodeStatus, lastIndex uint64) {
+	member.AppliedIndex = status.AppliedIndex
+	member.LastContact = status.LastContact
+	if lastIndex > status.AppliedIndex {
+		member.Lag = lastIndex - status.AppliedIndex
+	}
+}
+
+// handleNodeStatus returns the health of the current node.
+func (s *Service) handleNodeStatus(w http.ResponseWriter, r *http.Request) {
+	b, err := jsoniter.Marshal(s.store.NodeStatus())
 	if err != nil {
-		return err
+		writeError(w, http.StatusInternalServerError, ErrorCodeInternal, err)
+		return
 	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(b)
+}
 
-	r, err := http.NewRequest(http.MethodPut, fmt.Sprintf("%s://%s/policies/add", s.GetScheme(), s.Addr()), bytes.NewBuffer(b))
+// handlePromoteNode handles the request to make a nonvoter a voter, it is redirected to the leader.
+func (s *Service) handlePromoteNode(w http.ResponseWriter, r *http.Request) {
+	data, err := ioutil.ReadAll(r.Body)
 	if err != nil {
-		return err
+		writeError(w, http.StatusBadRequest, ErrorCodeBadRequest, err)
+		return
+	}
+	var cmd command.PromoteNodeRequest
+	err = jsoniter.Unmarshal(data, &cmd)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, ErrorCodeBadRequest, err)
+		return
 	}
+	err = s.store.PromoteNode(cmd.Id)
+	s.handleStoreResponse(err, w, r)
+}
 
-	resp, err := s.httpClient.Do(r)
+// handleDemoteNode handles the request to make a voter a nonvoter, it is redirected to the leader.
+func (s *Service) handleDemoteNode(w http.ResponseWriter, r *http.Request) {
+	data, err := ioutil.ReadAll(r.Body)
 	if err != nil {
-		return err
+		writeError(w, http.StatusBadRequest, ErrorCodeBadRequest, err)
+		return
 	}
-	if resp.StatusCode != http.StatusOK {
-		return errors.New(http.StatusText(http.StatusServiceUnavailable))
+	var cmd command.DemoteNodeRequest
+	err = jsoniter.Unmarshal(data, &cmd)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, ErrorCodeBadRequest, err)
+		return
 	}
+	err = s.store.DemoteNode(cmd.Id)
+	s.handleStoreResponse(err, w, r)
+}
 
-	return nil
+// handleTransferLeadership handles the request to transfer the leadership, it is redirected to the leader.
+func (s *Service) handleTransferLeadership(w http.ResponseWriter, r *http.Request) {
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, ErrorCodeBadRequest, err)
+		return
+	}
+	var cmd command.TransferLeadershipRequest
+	err = jsoniter.Unmarshal(data, &cmd)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, ErrorCodeBadRequest, err)
+		return
+	}
+	err = s.store.TransferLeadership(cmd.Id)
+	s.handleStoreResponse(err, w, r)
 }
 
-func (s *Service) DoRemovePolicyRequest(request *command.RemovePoliciesRequest) error {
-	b, err := jsoniter.Marshal(request)
+// handleTakeSnapshot handles the request to take a snapshot on the current node.
+func (s *Service) handleTakeSnapshot(w http.ResponseWriter, r *http.Request) {
+	meta, rc, err := s.store.Snapshot()
 	if err != nil {
-		return err
+		writeError(w, http.StatusInternalServerError, ErrorCodeInternal, err)
+		return
 	}
-	r, err := http.NewRequest(http.MethodPut, fmt.Sprintf("%s://%s/policies/remove", s.GetScheme(), s.Addr()), bytes.NewBuffer(b))
+	_ = rc.Close()
+
+	b, err := jsoniter.Marshal(newSnapshotResponse(meta))
 	if err != nil {
-		return err
+		writeError(w, http.StatusInternalServerError, ErrorCodeInternal, err)
+		return
 	}
+	w.Header().Set(RaftIndexHeader, strconv.FormatUint(meta.Index, 10))
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(b)
+}
 
-	resp, err := s.httpClient.Do(r)
+// handleDownloadSnapshot handles the request to take a snapshot on the current node and download it.
+// The raft log index of the snapshot is in the RaftIndexHeader header.
+func (s *Service) handleDownloadSnapshot(w http.ResponseWriter, r *http.Request) {
+	meta, rc, err := s.store.Snapshot()
 	if err != nil {
-		return err
+		writeError(w, http.StatusInternalServerError, ErrorCodeInternal, err)
+		return
 	}
-	if resp.StatusCode != http.StatusOK {
-		return errors.New(http.StatusText(http.StatusServiceUnavailable))
+	defer rc.Close()
+
+	w.Header().Set(RaftIndexHeader, strconv.FormatUint(meta.Index, 10))
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", meta.ID))
+	_, err = io.Copy(w, rc)
+	if err != nil {
+		// the status has been sent, the client gets a truncated body.
+		s.logger.Error("failed to download the snapshot", zap.Error(err))
 	}
+}
 
-	return nil
+func newSnapshotResponse(meta *raft.SnapshotMeta) *command.SnapshotResponse {
+	return &command.SnapshotResponse{
+		Id:    meta.ID,
+		Index: meta.Index,
+		Term:  meta.Term,
+		Size:  meta.Size,
+	}
 }
 
-func (s *Service) DoRemoveFilteredPolicyRequest(request *command.RemoveFilteredPolicyRequest) error {
-	b, err := jsoniter.Marshal(request)
+// handleHealth handles the request to check if the current node is healthy,
+// a node is healthy if it knows the leader of the cluster.
+func (s *Service) handleHealth(w http.ResponseWriter, r *http.Request) {
+	isLeader, leaderAddr := s.store.Leader()
+	if !isLeader && len(leaderAddr) == 0 {
+		writeError(w, http.StatusServiceUnavailable, ErrorCodeUnavailable, errors.New("the leader is unknown"))
+		return
+	}
+
+	b, err := jsoniter.Marshal(&command.HealthResponse{Leader: isLeader, LeaderAddress: leaderAddr})
 	if err != nil {
-		return err
+		writeError(w, http.StatusInternalServerError, ErrorCodeInternal, err)
+		return
 	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(b)
+}
 
-	r, err := http.NewRequest(http.MethodPut, fmt.Sprintf("%s://%s/policies/remove?type=filtered", s.GetScheme(), s.Addr()), bytes.NewBuffer(b))
+// handleStats handles the request to get the stats of the current node.
+func (s *Service) handleStats(w http.ResponseWriter, r *http.Request) {
+	stats, err := s.store.Stats()
 	if err != nil {
-		return err
+		writeError(w, http.StatusInternalServerError, ErrorCodeInternal, err)
+		return
 	}
 
-	resp, err := s.httpClient.Do(r)
+	b, err := jsoniter.Marshal(stats)
 	if err != nil {
-		return err
+		writeError(w, http.StatusInternalServerError, ErrorCodeInternal, err)
+		return
 	}
-	if resp.StatusCode != http.StatusOK {
-		return errors.New(http.StatusText(http.StatusServiceUnavailable))
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(b)
+}
+
+// handleBarrier handles the request to get an index for the linearizable read.
+func (s *Service) handleBarrier(w http.ResponseWriter, r *http.Request) {
+	index, err := s.store.Barrier(r.Context())
+	if err != nil {
+		s.handleStoreResponse(err, w, r)
+		return
 	}
 
-	return nil
+	b, err := jsoniter.Marshal(&command.BarrierResponse{Index: index})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, ErrorCodeInternal, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(b)
+}
+
+func (s *Service) Addr() string {
+	return s.ln.Addr().String()
 }
 
-func (s *Service) DoClearPolicyRequest() error {
-	r, err := http.NewRequest(http.MethodPut, fmt.Sprintf("%s://%s/policies/remove?type=all", s.GetScheme(), s.Addr()), nil)
+// withRequestTimeout bounds the context of the request by the RequestTimeoutHeader header.
+func withRequestTimeout(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		value := r.Header.Get(RequestTimeoutHeader)
+		if len(value) == 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		timeout, err := time.ParseDuration(value)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, ErrorCodeBadRequest, err)
+			return
+		}
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// doApplyRequest sends a write request to the current node, and returns the response of the applied write.
+// The deadline of ctx is passed to the server in the RequestTimeoutHeader header,
+// defaultRequestTimeout is used if ctx has no deadline.
+// The revision set by WithExpectedRevision is passed in the ExpectedRevisionHeader header,
+// and the request ID set by WithRequestID is passed in the RequestIDHeader header.
+func (s *Service) doApplyRequest(ctx context.Context, method string, path string, request interface{}) (*command.ApplyResponse, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultRequestTimeout)
+		defer cancel()
+	}
+
+	var body io.Reader
+	if request != nil {
+		b, err := jsoniter.Marshal(request)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewBuffer(b)
+	}
+
+	r, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s://%s%s", s.GetScheme(), s.Addr(), path), body)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	deadline, _ := ctx.Deadline()
+	r.Header.Set(RequestTimeoutHeader, time.Until(deadline).String())
+	if revision, ok := ExpectedRevision(ctx); ok {
+		r.Header.Set(ExpectedRevisionHeader, strconv.FormatUint(revision, 10))
+	}
+	if id, ok := RequestID(ctx); ok {
+		r.Header.Set(RequestIDHeader, id)
 	}
 
 	resp, err := s.httpClient.Do(r)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
-		return errors.New(http.StatusText(http.StatusServiceUnavailable))
+		return nil, ReadError(resp)
 	}
 
-	return nil
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	var applyResponse command.ApplyResponse
+	err = jsoniter.Unmarshal(data, &applyResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return &applyResponse, nil
+}
+
+func (s *Service) DoAddPolicyRequest(ctx context.Context, request *command.AddPoliciesRequest) (*command.ApplyResponse, error) {
+	return s.doApplyRequest(ctx, http.MethodPut, "/policies/add", request)
 }
 
-func (s *Service) DoUpdatePolicyRequest(request *command.UpdatePolicyRequest) error {
+func (s *Service) DoRemovePolicyRequest(ctx context.Context, request *command.RemovePoliciesRequest) (*command.ApplyResponse, error) {
+	return s.doApplyRequest(ctx, http.MethodPut, "/policies/remove", request)
+}
+
+func (s *Service) DoRemoveFilteredPolicyRequest(ctx context.Context, request *command.RemoveFilteredPolicyRequest) (*command.ApplyResponse, error) {
+	return s.doApplyRequest(ctx, http.MethodPut, "/policies/remove?type=filtered", request)
+}
+
+func (s *Service) DoClearPolicyRequest(ctx context.Context) (*command.ApplyResponse, error) {
+	return s.doApplyRequest(ctx, http.MethodPut, "/policies/remove?type=all", nil)
+}
+
+func (s *Service) DoUpdatePolicyRequest(ctx context.Context, request *command.UpdatePolicyRequest) (*command.ApplyResponse, error) {
+	return s.doApplyRequest(ctx, http.MethodPut, "/policies/update", request)
+}
+
+func (s *Service) DoUpdateFilteredPoliciesRequest(ctx context.Context, request *command.UpdateFilteredPoliciesRequest) (*command.ApplyResponse, error) {
+	return s.doApplyRequest(ctx, http.MethodPut, "/policies/update?type=filtered", request)
+}
+
+func (s *Service) DoUpdatePoliciesRequest(ctx context.Context, request *command.UpdatePoliciesRequest) (*command.ApplyResponse, error) {
+	return s.doApplyRequest(ctx, http.MethodPut, "/policies/update?type=batch", request)
+}
+
+func (s *Service) DoTransactionRequest(ctx context.Context, request *command.TransactionRequest) (*command.ApplyResponse, error) {
+	return s.doApplyRequest(ctx, http.MethodPut, "/policies/txn", request)
+}
+
+func (s *Service) DoSchedulePoliciesRequest(ctx context.Context, request *command.SchedulePoliciesRequest) (*command.ApplyResponse, error) {
+	return s.doApplyRequest(ctx, http.MethodPut, "/policies/pending/add", request)
+}
+
+func (s *Service) DoReschedulePoliciesRequest(ctx context.Context, request *command.ReschedulePoliciesRequest) (*command.ApplyResponse, error) {
+	return s.doApplyRequest(ctx, http.MethodPut, "/policies/pending/reschedule", request)
+}
+
+func (s *Service) DoCancelScheduledPoliciesRequest(ctx context.Context, request *command.CancelScheduledPoliciesRequest) (*command.ApplyResponse, error) {
+	return s.doApplyRequest(ctx, http.MethodPut, "/policies/pending/cancel", request)
+}
+
+func (s *Service) DoImportPoliciesRequest(ctx context.Context, policies []*command.Policy, replace bool) (*command.ApplyResponse, error) {
+	mode := "merge"
+	if replace {
+		mode = "replace"
+	}
+	if policies == nil {
+		policies = []*command.Policy{}
+	}
+	return s.doApplyRequest(ctx, http.MethodPost, "/policies/import?format=json&mode="+mode, policies)
+}
+
+func (s *Service) DoJoinNodeRequest(request *command.AddNodeRequest) error {
 	b, err := jsoniter.Marshal(request)
 	if err != nil {
 		return err
 	}
-	r, err := http.NewRequest(http.MethodPut, fmt.Sprintf("%s://%s/policies/update", s.GetScheme(), s.Addr()), bytes.NewBuffer(b))
+	ctx, cancel := context.WithTimeout(context.Background(), defaultRequestTimeout)
+	defer cancel()
+	r, err := http.NewRequestWithContext(ctx, http.MethodPut, fmt.Sprintf("%s://%s/nodes/join", s.GetScheme(), s.Addr()), bytes.NewBuffer(b))
 	if err != nil {
 		return err
 	}
@@ -457,19 +1193,22 @@ func (s *Service) DoUpdatePolicyRequest(request *command.UpdatePolicyRequest) er
 	if err != nil {
 		return err
 	}
+	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
-		return errors.New(http.StatusText(http.StatusServiceUnavailable))
+		return ReadError(resp)
 	}
 
 	return nil
 }
 
-func (s *Service) DoUpdateFilteredPoliciesRequest(request *command.UpdateFilteredPoliciesRequest) error {
+func (s *Service) DoRemoveNodeRequest(request *command.RemoveNodeRequest) error {
 	b, err := jsoniter.Marshal(request)
 	if err != nil {
 		return err
 	}
-	r, err := http.NewRequest(http.MethodPut, fmt.Sprintf("%s://%s/policies/update?type=filtered", s.GetScheme(), s.Addr()), bytes.NewBuffer(b))
+	ctx, cancel := context.WithTimeout(context.Background(), defaultRequestTimeout)
+	defer cancel()
+	r, err := http.NewRequestWithContext(ctx, http.MethodPut, fmt.Sprintf("%s://%s/nodes/remove", s.GetScheme(), s.Addr()), bytes.NewBuffer(b))
 	if err != nil {
 		return err
 	}
@@ -478,39 +1217,87 @@ func (s *Service) DoUpdateFilteredPoliciesRequest(request *command.UpdateFiltere
 	if err != nil {
 		return err
 	}
+	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
-		return errors.New(http.StatusText(http.StatusServiceUnavailable))
+		return ReadError(resp)
 	}
+
 	return nil
 }
 
-func (s *Service) DoUpdatePoliciesRequest(request *command.UpdatePoliciesRequest) error {
-	b, err := jsoniter.Marshal(request)
+// DoListNodesRequest returns the members of the cluster with their health from the leader.
+func (s *Service) DoListNodesRequest(ctx context.Context) (*command.ListNodesResponse, error) {
+	r, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s://%s/nodes", s.GetScheme(), s.Addr()), nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	r, err := http.NewRequest(http.MethodPut, fmt.Sprintf("%s://%s/policies/update?type=batch", s.GetScheme(), s.Addr()), bytes.NewBuffer(b))
+
+	resp, err := s.httpClient.Do(r)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, ReadError(resp)
+	}
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	var nodes command.ListNodesResponse
+	err = jsoniter.Unmarshal(data, &nodes)
+	if err != nil {
+		return nil, err
+	}
+	return &nodes, nil
+}
+
+// doNodeStatusRequest returns the health of the node at the given address.
+func (s *Service) doNodeStatusRequest(ctx context.Context, address string) (*command.NodeStatus, error) {
+	r, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s://%s/nodes/status", s.GetScheme(), address), nil)
+	if err != nil {
+		return nil, err
 	}
 
 	resp, err := s.httpClient.Do(r)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
-		return errors.New(http.StatusText(http.StatusServiceUnavailable))
+		return nil, ReadError(resp)
 	}
 
-	return nil
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	var status command.NodeStatus
+	err = jsoniter.Unmarshal(data, &status)
+	if err != nil {
+		return nil, err
+	}
+	return &status, nil
 }
 
-func (s *Service) DoJoinNodeRequest(request *command.AddNodeRequest) error {
+// DoPromoteNodeRequest asks the leader to make a nonvoter a voter.
+func (s *Service) DoPromoteNodeRequest(ctx context.Context, request *command.PromoteNodeRequest) error {
+	return s.doNodeRequest(ctx, "/nodes/promote", request)
+}
+
+// DoDemoteNodeRequest asks the leader to make a voter a nonvoter.
+func (s *Service) DoDemoteNodeRequest(ctx context.Context, request *command.DemoteNodeRequest) error {
+	return s.doNodeRequest(ctx, "/nodes/demote", request)
+}
+
+// doNodeRequest sends a request to change the membership to the given path of the current node.
+func (s *Service) doNodeRequest(ctx context.Context, path string, request interface{}) error {
 	b, err := jsoniter.Marshal(request)
 	if err != nil {
 		return err
 	}
-	r, err := http.NewRequest(http.MethodPut, fmt.Sprintf("%s://%s/nodes/join", s.GetScheme(), s.Addr()), bytes.NewBuffer(b))
+	r, err := http.NewRequestWithContext(ctx, http.MethodPut, fmt.Sprintf("%s://%s%s", s.GetScheme(), s.Addr(), path), bytes.NewBuffer(b))
 	if err != nil {
 		return err
 	}
@@ -519,19 +1306,21 @@ func (s *Service) DoJoinNodeRequest(request *command.AddNodeRequest) error {
 	if err != nil {
 		return err
 	}
+	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
-		return errors.New(http.StatusText(http.StatusServiceUnavailable))
+		return ReadError(resp)
 	}
 
 	return nil
 }
 
-func (s *Service) DoRemoveNodeRequest(request *command.RemoveNodeRequest) error {
+// DoTransferLeadershipRequest asks the leader to transfer the leadership.
+func (s *Service) DoTransferLeadershipRequest(ctx context.Context, request *command.TransferLeadershipRequest) error {
 	b, err := jsoniter.Marshal(request)
 	if err != nil {
 		return err
 	}
-	r, err := http.NewRequest(http.MethodPut, fmt.Sprintf("%s://%s/nodes/remove", s.GetScheme(), s.Addr()), bytes.NewBuffer(b))
+	r, err := http.NewRequestWithContext(ctx, http.MethodPut, fmt.Sprintf("%s://%s/nodes/transfer-leadership", s.GetScheme(), s.Addr()), bytes.NewBuffer(b))
 	if err != nil {
 		return err
 	}
@@ -540,34 +1329,110 @@ func (s *Service) DoRemoveNodeRequest(request *command.RemoveNodeRequest) error
 	if err != nil {
 		return err
 	}
+	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
-		return errors.New(http.StatusText(http.StatusServiceUnavailable))
+		return ReadError(resp)
 	}
 
 	return nil
 }
 
-func DoJoinNodeRequest(clusterAddress string, nodeID string, nodeAddress string, tlsConfig *tls.Config) error {
-	tr := &http.Transport{
-		TLSClientConfig: tlsConfig,
+// DoBarrierRequest asks the leader for the index to read after, the deadline of ctx is passed in the RequestTimeoutHeader header.
+func (s *Service) DoBarrierRequest(ctx context.Context) (uint64, error) {
+	r, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s://%s/reads/barrier", s.GetScheme(), s.Addr()), nil)
+	if err != nil {
+		return 0, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		r.Header.Set(RequestTimeoutHeader, time.Until(deadline).String())
 	}
-	client := http.Client{Transport: tr}
 
-	data := &command.AddNodeRequest{
-		Address: nodeAddress,
-		Id:      nodeID,
+	resp, err := s.httpClient.Do(r)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, ReadError(resp)
 	}
 
-	b, err := jsoniter.Marshal(data)
+	data, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return err
+		return 0, err
 	}
+	var barrier command.BarrierResponse
+	err = jsoniter.Unmarshal(data, &barrier)
+	if err != nil {
+		return 0, err
+	}
+
+	return barrier.Index, nil
+}
+
+// DoJoinNodeRequest asks the node at clusterAddress to join a node with the given role to its cluster,
+// the request is redirected to the leader.
+func DoJoinNodeRequest(ctx context.Context, clusterAddress string, nodeID string, nodeAddress string, role string, tlsConfig *tls.Config) error {
+	return DoJoinClusterRequest(ctx, []string{clusterAddress}, nodeID, nodeAddress, role, tlsConfig)
+}
+
+// DoJoinClusterRequest asks the nodes at clusterAddresses in turn to join a node with the given role to their cluster,
+// the requests are redirected to the leader. If all the nodes fail, the round is retried with exponential backoff
+// until ctx is done, and the errors of the nodes in the last round are returned.
+// The errors of the requests rejected by the cluster, such as an invalid role, are not retried.
+func DoJoinClusterRequest(ctx context.Context, clusterAddresses []string, nodeID string, nodeAddress string, role string, tlsConfig *tls.Config) error {
+	if len(clusterAddresses) == 0 {
+		return errors.New("no cluster address is provided")
+	}
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: tlsConfig,
+		},
+	}
+	defer client.CloseIdleConnections()
 
 	scheme := "https"
 	if tlsConfig == nil {
 		scheme = "http"
 	}
-	r, err := http.NewRequest(http.MethodPut, fmt.Sprintf("%s://%s/nodes/join", scheme, clusterAddress), bytes.NewBuffer(b))
+
+	b, err := jsoniter.Marshal(&command.AddNodeRequest{
+		Address: nodeAddress,
+		Id:      nodeID,
+		Role:    role,
+	})
+	if err != nil {
+		return err
+	}
+
+	var lastErr error
+	err = backoff.Retry(func() error {
+		var ret error
+		for _, address := range clusterAddresses {
+			err := doJoinNodeRequest(ctx, client, fmt.Sprintf("%s://%s/nodes/join", scheme, address), b)
+			if err == nil {
+				return nil
+			}
+			ret = multierror.Append(ret, errors.Wrapf(err, "failed to join by %s", address))
+			if e, ok := err.(*Error); ok && e.StatusCode < http.StatusInternalServerError {
+				lastErr = ret
+				return backoff.Permanent(ret)
+			}
+		}
+		lastErr = ret
+		return ret
+	}, backoff.WithContext(backoff.NewExponentialBackOff(), ctx))
+	if err != nil && lastErr != nil {
+		return errors.Wrapf(lastErr, "failed to join the cluster by any of %s", strings.Join(clusterAddresses, ", "))
+	}
+	return err
+}
+
+// doJoinNodeRequest sends a request to join a node to the given URL.
+func doJoinNodeRequest(ctx context.Context, client *http.Client, url string, body []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, defaultRequestTimeout)
+	defer cancel()
+	r, err := http.NewRequestWithContext(ctx, http.MethodPut, url, bytes.NewReader(body))
 	if err != nil {
 		return err
 	}
@@ -576,8 +1441,9 @@ func DoJoinNodeRequest(clusterAddress string, nodeID string, nodeAddress string,
 	if err != nil {
 		return err
 	}
+	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
-		return errors.New(http.StatusText(http.StatusServiceUnavailable))
+		return ReadError(resp)
 	}
 
 	return nil
@@ -71,11 +71,29 @@ func TestNotLeaderError(t *testing.T) {
 	s.handleStoreResponse(errors.New("test error"), w, httptest.NewRequest(http.MethodPut, "https://testing", nil))
 	assert.Equal(t, w.Code, http.StatusServiceUnavailable)
 
+	w = httptest.NewRecorder()
+	s.handleStoreResponse(NewRevisionConflictError(2, 1), w, httptest.NewRequest(http.MethodPut, "https://testing", nil))
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	w = httptest.NewRecorder()
+	s.handleStoreResponse(NewApplyError(errors.New("test error")), w, httptest.NewRequest(http.MethodPut, "https://testing", nil))
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	var e Error
+	err = jsoniter.Unmarshal(w.Body.Bytes(), &e)
+	assert.NoError(t, err)
+	assert.Equal(t, ErrorCodeApplyFailed, e.Code)
+
 	store.EXPECT().Leader().Return(false, "127.0.0.1:6790")
 	w = httptest.NewRecorder()
 	s.handleStoreResponse(raft.ErrNotLeader, w, httptest.NewRequest(http.MethodPut, "https://testing/add", nil))
 	assert.Equal(t, w.Header().Get("Location"), "https://127.0.0.1:6790/add")
 	assert.Equal(t, w.Code, http.StatusTemporaryRedirect)
+
+	store.EXPECT().Leader().Return(false, "127.0.0.1:6790")
+	w = httptest.NewRecorder()
+	s.handleStoreResponse(fmt.Errorf("failed to apply: %w", raft.ErrNotLeader), w, httptest.NewRequest(http.MethodPut, "https://testing/add", nil))
+	assert.Equal(t, "https://127.0.0.1:6790/add", w.Header().Get("Location"))
+	assert.Equal(t, http.StatusTemporaryRedirect, w.Code)
 }
 
 func TestAddPolicy(t *testing.T) {
@@ -103,7 +121,7 @@ func TestAddPolicy(t *testing.T) {
 		PType: "p",
 		Rules: []*command.StringArray{{Items: []string{"role:admin", "/", "*"}}},
 	}
-	store.EXPECT().AddPolicies(addPolicyRequest).Return(nil)
+	store.EXPECT().AddPolicies(gomock.Any(), addPolicyRequest).Return(&command.ApplyResponse{Index: 1}, nil)
 
 	b, err := jsoniter.Marshal(addPolicyRequest)
 	assert.NoError(t, err)
@@ -113,6 +131,453 @@ func TestAddPolicy(t *testing.T) {
 	resp, err := ts.Client().Do(r)
 	assert.NoError(t, err)
 	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "1", resp.Header.Get(RaftIndexHeader))
+
+	b, err = ioutil.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	var applyResponse command.ApplyResponse
+	err = jsoniter.Unmarshal(b, &applyResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), applyResponse.Index)
+}
+
+func TestDoAddPolicyRequest(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	store := mocks.NewMockStore(ctl)
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	s, err := NewService(zap.NewExample(), ln, nil, store)
+	assert.NoError(t, err)
+
+	err = s.Start()
+	assert.NoError(t, err)
+	defer s.Stop(context.Background())
+
+	addPolicyRequest := &command.AddPoliciesRequest{
+		Sec:   "p",
+		PType: "p",
+		Rules: []*command.StringArray{{Items: []string{"role:admin", "/", "*"}}},
+	}
+
+	store.EXPECT().AddPolicies(gomock.Any(), addPolicyRequest).Return(&command.ApplyResponse{Index: 2}, nil)
+	resp, err := s.DoAddPolicyRequest(context.Background(), addPolicyRequest)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), resp.Index)
+
+	store.EXPECT().AddPolicies(gomock.Any(), addPolicyRequest).Return(nil, NewApplyError(errors.New("invalid rule")))
+	_, err = s.DoAddPolicyRequest(context.Background(), addPolicyRequest)
+	assert.Error(t, err)
+	e, ok := err.(*Error)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusUnprocessableEntity, e.StatusCode)
+	assert.Equal(t, ErrorCodeApplyFailed, e.Code)
+	assert.Contains(t, e.Message, "invalid rule")
+
+	store.EXPECT().AddPolicies(gomock.Any(), addPolicyRequest).DoAndReturn(func(ctx context.Context, request *command.AddPoliciesRequest) (*command.ApplyResponse, error) {
+		deadline, ok := ctx.Deadline()
+		assert.True(t, ok)
+		assert.True(t, time.Until(deadline) <= time.Second)
+		return &command.ApplyResponse{Index: 3}, nil
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	resp, err = s.DoAddPolicyRequest(ctx, addPolicyRequest)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), resp.Index)
+
+	store.EXPECT().AddPolicies(gomock.Any(), addPolicyRequest).DoAndReturn(func(ctx context.Context, request *command.AddPoliciesRequest) (*command.ApplyResponse, error) {
+		revision, ok := ExpectedRevision(ctx)
+		assert.True(t, ok)
+		return nil, NewRevisionConflictError(revision, 4)
+	})
+	_, err = s.DoAddPolicyRequest(WithExpectedRevision(context.Background(), 5), addPolicyRequest)
+	assert.Error(t, err)
+	e, ok = err.(*Error)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusConflict, e.StatusCode)
+	assert.Equal(t, ErrorCodeConflict, e.Code)
+
+	store.EXPECT().AddPolicies(gomock.Any(), addPolicyRequest).DoAndReturn(func(ctx context.Context, request *command.AddPoliciesRequest) (*command.ApplyResponse, error) {
+		id, ok := RequestID(ctx)
+		assert.True(t, ok)
+		assert.Equal(t, "request-1", id)
+		return &command.ApplyResponse{Index: 5}, nil
+	})
+	_, err = s.DoAddPolicyRequest(WithRequestID(context.Background(), "request-1"), addPolicyRequest)
+	assert.NoError(t, err)
+}
+
+func TestTransaction(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	store := mocks.NewMockStore(ctl)
+
+	ts := httptest.NewUnstartedServer(nil)
+	ts.EnableHTTP2 = true
+	ts.StartTLS()
+	defer ts.Close()
+
+	ln, err := tls.Listen("tcp", "127.0.0.1:0", ts.TLS)
+	assert.NoError(t, err)
+	s, err := NewService(zap.NewExample(), ln, ts.TLS, store)
+	assert.NoError(t, err)
+
+	err = s.Start()
+	assert.NoError(t, err)
+	defer s.Stop(context.Background())
+
+	transactionRequest := &command.TransactionRequest{
+		Operations: []*command.TransactionOperation{
+			{
+				RemoveFilteredPolicy: &command.RemoveFilteredPolicyRequest{
+					Sec:         "p",
+					PType:       "p",
+					FieldIndex:  0,
+					FieldValues: []string{"role:admin"},
+				},
+			},
+			{
+				AddPolicies: &command.AddPoliciesRequest{
+					Sec:   "p",
+					PType: "p",
+					Rules: []*command.StringArray{{Items: []string{"role:admin", "/", "*"}}},
+				},
+			},
+		},
+	}
+	store.EXPECT().Transaction(gomock.Any(), transactionRequest).Return(&command.ApplyResponse{Index: 1, Effected: true}, nil)
+
+	b, err := jsoniter.Marshal(transactionRequest)
+	assert.NoError(t, err)
+	r, err := http.NewRequest(http.MethodPut, fmt.Sprintf("https://%s/policies/txn", s.Addr()), bytes.NewBuffer(b))
+	assert.NoError(t, err)
+
+	resp, err := ts.Client().Do(r)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestPendingPolicies(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	store := mocks.NewMockStore(ctl)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	s, err := NewService(zap.NewExample(), ln, nil, store)
+	assert.NoError(t, err)
+
+	err = s.Start()
+	assert.NoError(t, err)
+	defer s.Stop(context.Background())
+
+	scheduleRequest := &command.SchedulePoliciesRequest{
+		Sec:        "p",
+		PType:      "p",
+		Rules:      []*command.StringArray{{Items: []string{"role:admin", "/", "*"}}},
+		ActivateAt: 100,
+	}
+	store.EXPECT().SchedulePolicies(gomock.Any(), scheduleRequest).Return(&command.ApplyResponse{Index: 1, Effected: true}, nil)
+	resp, err := s.DoSchedulePoliciesRequest(context.Background(), scheduleRequest)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), resp.Index)
+
+	rescheduleRequest := &command.ReschedulePoliciesRequest{
+		Sec:        "p",
+		PType:      "p",
+		Rules:      []*command.StringArray{{Items: []string{"role:admin", "/", "*"}}},
+		ActivateAt: 200,
+	}
+	store.EXPECT().ReschedulePolicies(gomock.Any(), rescheduleRequest).Return(&command.ApplyResponse{Index: 2, Effected: true}, nil)
+	resp, err = s.DoReschedulePoliciesRequest(context.Background(), rescheduleRequest)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), resp.Index)
+
+	pending := []*command.PendingPolicy{{Sec: "p", PType: "p", Rule: []string{"role:admin", "/", "*"}, ActivateAt: 200}}
+	store.EXPECT().PendingPolicies().Return(pending, nil)
+	listResp, err := http.Get(fmt.Sprintf("http://%s/policies/pending", s.Addr()))
+	assert.NoError(t, err)
+	defer listResp.Body.Close()
+	assert.Equal(t, http.StatusOK, listResp.StatusCode)
+	data, err := ioutil.ReadAll(listResp.Body)
+	assert.NoError(t, err)
+	var pendingResponse command.PendingPoliciesResponse
+	err = jsoniter.Unmarshal(data, &pendingResponse)
+	assert.NoError(t, err)
+	assert.Len(t, pendingResponse.Policies, 1)
+	assert.Equal(t, int64(200), pendingResponse.Policies[0].ActivateAt)
+
+	cancelRequest := &command.CancelScheduledPoliciesRequest{
+		Sec:   "p",
+		PType: "p",
+		Rules: []*command.StringArray{{Items: []string{"role:admin", "/", "*"}}},
+	}
+	store.EXPECT().CancelScheduledPolicies(gomock.Any(), cancelRequest).Return(&command.ApplyResponse{Index: 3, Effected: true}, nil)
+	resp, err = s.DoCancelScheduledPoliciesRequest(context.Background(), cancelRequest)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), resp.Index)
+}
+
+func TestExportImportPolicies(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	store := mocks.NewMockStore(ctl)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	s, err := NewService(zap.NewExample(), ln, nil, store)
+	assert.NoError(t, err)
+
+	err = s.Start()
+	assert.NoError(t, err)
+	defer s.Stop(context.Background())
+
+	policies := []*command.Policy{
+		{Sec: "p", PType: "p", Rule: []string{"role:admin", "/", "*"}},
+		{Sec: "g", PType: "g", Rule: []string{"alice", "role:admin"}},
+	}
+	forEachPolicy := func(fn func(policy *command.Policy) error) error {
+		for _, policy := range policies {
+			if err := fn(policy); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	store.EXPECT().ForEachPolicy(gomock.Any()).DoAndReturn(forEachPolicy)
+	exportResp, err := http.Get(fmt.Sprintf("http://%s/policies/export", s.Addr()))
+	assert.NoError(t, err)
+	defer exportResp.Body.Close()
+	assert.Equal(t, http.StatusOK, exportResp.StatusCode)
+	assert.Equal(t, "text/csv", exportResp.Header.Get("Content-Type"))
+	data, err := ioutil.ReadAll(exportResp.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, "p,role:admin,/,*\ng,alice,role:admin\n", string(data))
+
+	store.EXPECT().ForEachPolicy(gomock.Any()).DoAndReturn(forEachPolicy)
+	exportResp, err = http.Get(fmt.Sprintf("http://%s/policies/export?format=json", s.Addr()))
+	assert.NoError(t, err)
+	defer exportResp.Body.Close()
+	assert.Equal(t, http.StatusOK, exportResp.StatusCode)
+	data, err = ioutil.ReadAll(exportResp.Body)
+	assert.NoError(t, err)
+	exported, err := ReadPolicies(bytes.NewReader(data), PolicyFormatJSON)
+	assert.NoError(t, err)
+	assert.Len(t, exported, 2)
+	assert.Equal(t, "g", exported[1].PType)
+	assert.Equal(t, []string{"alice", "role:admin"}, exported[1].Rule)
+
+	exportResp, err = http.Get(fmt.Sprintf("http://%s/policies/export?format=xml", s.Addr()))
+	assert.NoError(t, err)
+	defer exportResp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, exportResp.StatusCode)
+
+	store.EXPECT().ImportPolicies(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, request *command.ImportPoliciesRequest) (*command.ApplyResponse, error) {
+		assert.True(t, request.Replace)
+		assert.Len(t, request.Policies, 2)
+		assert.Equal(t, "g", request.Policies[1].Sec)
+		return &command.ApplyResponse{Index: 1, Effected: true}, nil
+	})
+	resp, err := s.DoImportPoliciesRequest(context.Background(), exported, true)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), resp.Index)
+
+	store.EXPECT().ImportPolicies(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, request *command.ImportPoliciesRequest) (*command.ApplyResponse, error) {
+		assert.False(t, request.Replace)
+		assert.Equal(t, []string{"role:admin", "/", "*"}, request.Policies[0].Rule)
+		return &command.ApplyResponse{Index: 2, Effected: true}, nil
+	})
+	importResp, err := http.Post(fmt.Sprintf("http://%s/policies/import", s.Addr()), "text/csv", bytes.NewBufferString("# admin\np, role:admin, /, *\n"))
+	assert.NoError(t, err)
+	defer importResp.Body.Close()
+	assert.Equal(t, http.StatusOK, importResp.StatusCode)
+
+	importResp, err = http.Post(fmt.Sprintf("http://%s/policies/import?mode=append", s.Addr()), "text/csv", bytes.NewBufferString("p, role:admin, /, *\n"))
+	assert.NoError(t, err)
+	defer importResp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, importResp.StatusCode)
+}
+
+func TestListPolicies(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	store := mocks.NewMockStore(ctl)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	s, err := NewService(zap.NewExample(), ln, nil, store)
+	assert.NoError(t, err)
+
+	err = s.Start()
+	assert.NoError(t, err)
+	defer s.Stop(context.Background())
+
+	policies := []*command.Policy{
+		{Sec: "g", PType: "g", Rule: []string{"alice", "role:admin"}},
+		{Sec: "p", PType: "p", Rule: []string{"role:admin", "/", "GET"}},
+		{Sec: "p", PType: "p", Rule: []string{"role:admin", "/", "POST"}},
+		{Sec: "p", PType: "p", Rule: []string{"role:user", "/", "GET"}},
+	}
+	store.EXPECT().ForEachPolicy(gomock.Any()).DoAndReturn(func(fn func(policy *command.Policy) error) error {
+		for _, policy := range policies {
+			if err := fn(policy); err != nil {
+				return err
+			}
+		}
+		return nil
+	}).AnyTimes()
+	store.EXPECT().Revision().Return(uint64(4)).AnyTimes()
+
+	list := func(path string, query *PolicyQuery) (int, *command.ListPoliciesResponse) {
+		resp, err := http.Get(fmt.Sprintf("http://%s%s?%s", s.Addr(), path, query.Values().Encode()))
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		data, err := ioutil.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		var listResponse command.ListPoliciesResponse
+		if resp.StatusCode == http.StatusOK {
+			assert.NoError(t, jsoniter.Unmarshal(data, &listResponse))
+		}
+		return resp.StatusCode, &listResponse
+	}
+
+	status, resp := list("/policies", &PolicyQuery{Sec: "p", PType: "p", FieldIndex: 2, FieldValues: []string{"GET"}})
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, int64(2), resp.Total)
+	assert.Equal(t, uint64(4), resp.Revision)
+	assert.Equal(t, []string{"role:admin", "/", "GET"}, resp.Policies[0].Rule)
+	assert.Equal(t, []string{"role:user", "/", "GET"}, resp.Policies[1].Rule)
+
+	status, resp = list("/policies", &PolicyQuery{FieldValues: []string{"role:admin", ""}, Offset: 1, Limit: 1})
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, int64(2), resp.Total)
+	assert.Len(t, resp.Policies, 1)
+	assert.Equal(t, []string{"role:admin", "/", "POST"}, resp.Policies[0].Rule)
+
+	status, resp = list("/policies/grouping", &PolicyQuery{})
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, int64(1), resp.Total)
+	assert.Equal(t, []string{"alice", "role:admin"}, resp.Policies[0].Rule)
+
+	store.EXPECT().Leader().Return(true, s.Addr())
+	store.EXPECT().Barrier(gomock.Any()).Return(uint64(9), nil)
+	store.EXPECT().WaitForAppliedIndex(gomock.Any(), uint64(9)).Return(nil)
+	status, resp = list("/policies", &PolicyQuery{PType: "g", Consistency: ConsistencyLinearizable})
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, int64(1), resp.Total)
+
+	store.EXPECT().Leader().Return(false, "").Times(2)
+	status, _ = list("/policies", &PolicyQuery{Consistency: ConsistencyLeader})
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+
+	status, _ = list("/policies", &PolicyQuery{Consistency: "eventual"})
+	assert.Equal(t, http.StatusBadRequest, status)
+}
+
+func TestEnforce(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	store := mocks.NewMockStore(ctl)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	s, err := NewService(zap.NewExample(), ln, nil, store)
+	assert.NoError(t, err)
+
+	err = s.Start()
+	assert.NoError(t, err)
+	defer s.Stop(context.Background())
+
+	store.EXPECT().EnforceEx("alice", "/", "GET").Return(true, []string{"alice", "/", "GET"}, nil)
+	resp, err := http.Post(fmt.Sprintf("http://%s/enforce", s.Addr()), "application/json", bytes.NewBufferString(`{"values":["alice","/","GET"]}`))
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	data, err := ioutil.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	var enforceResponse command.EnforceResponse
+	err = jsoniter.Unmarshal(data, &enforceResponse)
+	assert.NoError(t, err)
+	assert.True(t, enforceResponse.Allowed)
+	assert.Equal(t, []string{"alice", "/", "GET"}, enforceResponse.Explanation)
+
+	store.EXPECT().Leader().Return(true, s.Addr())
+	store.EXPECT().Barrier(gomock.Any()).Return(uint64(3), nil)
+	store.EXPECT().WaitForAppliedIndex(gomock.Any(), uint64(3)).Return(nil)
+	store.EXPECT().EnforceEx("alice", "/", "GET").Return(true, []string{"alice", "/", "GET"}, nil)
+	store.EXPECT().EnforceEx("bob", "/", "GET").Return(false, nil, nil)
+	resp, err = http.Post(fmt.Sprintf("http://%s/enforce/batch?consistency=linearizable", s.Addr()), "application/json",
+		bytes.NewBufferString(`{"requests":[{"values":["alice","/","GET"]},{"values":["bob","/","GET"]}]}`))
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	data, err = ioutil.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	var batchResponse command.BatchEnforceResponse
+	err = jsoniter.Unmarshal(data, &batchResponse)
+	assert.NoError(t, err)
+	assert.Len(t, batchResponse.Responses, 2)
+	assert.True(t, batchResponse.Responses[0].Allowed)
+	assert.False(t, batchResponse.Responses[1].Allowed)
+
+	store.EXPECT().EnforceEx("alice").Return(false, nil, errors.New("invalid request size"))
+	resp, err = http.Post(fmt.Sprintf("http://%s/enforce", s.Addr()), "application/json", bytes.NewBufferString(`{"values":["alice"]}`))
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestSnapshot(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	store := mocks.NewMockStore(ctl)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	s, err := NewService(zap.NewExample(), ln, nil, store)
+	assert.NoError(t, err)
+
+	err = s.Start()
+	assert.NoError(t, err)
+	defer s.Stop(context.Background())
+
+	meta := &raft.SnapshotMeta{ID: "2-10-1", Index: 10, Term: 2, Size: 4}
+	store.EXPECT().Snapshot().Return(meta, ioutil.NopCloser(bytes.NewBufferString("data")), nil).Times(2)
+
+	resp, err := http.Post(fmt.Sprintf("http://%s/snapshot", s.Addr()), "application/json", nil)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	data, err := ioutil.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	var snapshotResponse command.SnapshotResponse
+	err = jsoniter.Unmarshal(data, &snapshotResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(10), snapshotResponse.Index)
+	assert.Equal(t, "2-10-1", snapshotResponse.Id)
+
+	resp, err = http.Get(fmt.Sprintf("http://%s/snapshot", s.Addr()))
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, "10", resp.Header.Get(RaftIndexHeader))
+	data, err = ioutil.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, "data", string(data))
+
+	store.EXPECT().Leader().Return(false, "")
+	resp, err = http.Get(fmt.Sprintf("http://%s/health", s.Addr()))
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
 }
 
 func TestRemovePolicy(t *testing.T) {
@@ -140,7 +605,7 @@ func TestRemovePolicy(t *testing.T) {
 		PType: "p",
 		Rules: []*command.StringArray{{Items: []string{"role:admin", "/", "*"}}},
 	}
-	store.EXPECT().RemovePolicies(removePolicyRequest).Return(nil)
+	store.EXPECT().RemovePolicies(gomock.Any(), removePolicyRequest).Return(&command.ApplyResponse{Index: 1}, nil)
 
 	b, err := jsoniter.Marshal(removePolicyRequest)
 	assert.NoError(t, err)
@@ -178,7 +643,7 @@ func TestRemoveFilteredPolicy(t *testing.T) {
 		FieldIndex:  0,
 		FieldValues: []string{"role:admin"},
 	}
-	store.EXPECT().RemoveFilteredPolicy(removeFilteredPolicyRequest).Return(nil)
+	store.EXPECT().RemoveFilteredPolicy(gomock.Any(), removeFilteredPolicyRequest).Return(&command.ApplyResponse{Index: 1}, nil)
 
 	b, err := jsoniter.Marshal(removeFilteredPolicyRequest)
 	assert.NoError(t, err)
@@ -216,7 +681,7 @@ func TestUpdatePolicy(t *testing.T) {
 		OldRule: []string{"role:admin", "/", "*"},
 		NewRule: []string{"role:admin", "/admin", "*"},
 	}
-	store.EXPECT().UpdatePolicy(updatePolicyRequest).Return(nil)
+	store.EXPECT().UpdatePolicy(gomock.Any(), updatePolicyRequest).Return(&command.ApplyResponse{Index: 1}, nil)
 
 	b, err := jsoniter.Marshal(updatePolicyRequest)
 	assert.NoError(t, err)
@@ -248,7 +713,7 @@ func TestClearPolicy(t *testing.T) {
 	assert.NoError(t, err)
 	defer s.Stop(context.Background())
 
-	store.EXPECT().ClearPolicy().Return(nil)
+	store.EXPECT().ClearPolicy(gomock.Any()).Return(&command.ApplyResponse{Index: 1}, nil)
 
 	r, err := http.NewRequest(http.MethodPut, fmt.Sprintf("https://%s/policies/remove?type=all", s.Addr()), nil)
 	assert.NoError(t, err)
@@ -282,7 +747,7 @@ func TestJoinNode(t *testing.T) {
 		Id:      "test-main",
 		Address: "10.0.7.10",
 	}
-	store.EXPECT().JoinNode(addNodeRequest.Id, addNodeRequest.Address).Return(nil)
+	store.EXPECT().JoinNode(addNodeRequest.Id, addNodeRequest.Address, RoleVoter).Return(nil)
 
 	b, err := jsoniter.Marshal(addNodeRequest)
 	assert.NoError(t, err)
@@ -292,6 +757,198 @@ func TestJoinNode(t *testing.T) {
 	resp, err := ts.Client().Do(r)
 	assert.NoError(t, err)
 	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	addNodeRequest.Role = RoleNonvoter
+	store.EXPECT().JoinNode(addNodeRequest.Id, addNodeRequest.Address, RoleNonvoter).Return(nil)
+	b, err = jsoniter.Marshal(addNodeRequest)
+	assert.NoError(t, err)
+	r, err = http.NewRequest(http.MethodPut, fmt.Sprintf("https://%s/nodes/join", s.Addr()), bytes.NewReader(b))
+	assert.NoError(t, err)
+	resp, err = ts.Client().Do(r)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	addNodeRequest.Role = "observer"
+	b, err = jsoniter.Marshal(addNodeRequest)
+	assert.NoError(t, err)
+	r, err = http.NewRequest(http.MethodPut, fmt.Sprintf("https://%s/nodes/join", s.Addr()), bytes.NewReader(b))
+	assert.NoError(t, err)
+	resp, err = ts.Client().Do(r)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestDoJoinClusterRequest(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	newService := func(store Store) *Service {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		assert.NoError(t, err)
+		s, err := NewService(zap.NewExample(), ln, nil, store)
+		assert.NoError(t, err)
+		err = s.Start()
+		assert.NoError(t, err)
+		return s
+	}
+	leaderStore := mocks.NewMockStore(ctl)
+	leader := newService(leaderStore)
+	defer leader.Stop(context.Background())
+	followerStore := mocks.NewMockStore(ctl)
+	follower := newService(followerStore)
+	defer follower.Stop(context.Background())
+
+	newUnreachableAddress := func() string {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		assert.NoError(t, err)
+		defer ln.Close()
+		return ln.Addr().String()
+	}
+	unreachable := newUnreachableAddress()
+
+	// the unreachable node is skipped, and the follower redirects the request to the leader.
+	followerStore.EXPECT().JoinNode("node-3", "127.0.0.1:6890", RoleVoter).Return(raft.ErrNotLeader)
+	followerStore.EXPECT().Leader().Return(false, leader.Addr())
+	leaderStore.EXPECT().JoinNode("node-3", "127.0.0.1:6890", RoleVoter).Return(nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err := DoJoinClusterRequest(ctx, []string{unreachable, follower.Addr()}, "node-3", "127.0.0.1:6890", RoleVoter, nil)
+	assert.NoError(t, err)
+
+	// the request rejected by the cluster is not retried.
+	err = DoJoinClusterRequest(ctx, []string{leader.Addr()}, "node-3", "127.0.0.1:6890", "observer", nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid role")
+
+	other := newUnreachableAddress()
+	ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	err = DoJoinClusterRequest(ctx, []string{unreachable, other}, "node-3", "127.0.0.1:6890", RoleVoter, nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to join the cluster by any of "+unreachable+", "+other)
+	assert.Contains(t, err.Error(), "failed to join by "+other)
+
+	err = DoJoinClusterRequest(ctx, nil, "node-3", "127.0.0.1:6890", RoleVoter, nil)
+	assert.EqualError(t, err, "no cluster address is provided")
+}
+
+func TestPromoteDemoteNode(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	store := mocks.NewMockStore(ctl)
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	s, err := NewService(zap.NewExample(), ln, nil, store)
+	assert.NoError(t, err)
+	err = s.Start()
+	assert.NoError(t, err)
+	defer s.Stop(context.Background())
+
+	store.EXPECT().PromoteNode("node-2").Return(nil)
+	err = s.DoPromoteNodeRequest(context.Background(), &command.PromoteNodeRequest{Id: "node-2"})
+	assert.NoError(t, err)
+
+	store.EXPECT().DemoteNode("node-3").Return(errors.New("the server node-3 is not in the cluster"))
+	err = s.DoDemoteNodeRequest(context.Background(), &command.DemoteNodeRequest{Id: "node-3"})
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, err.(*Error).StatusCode)
+}
+
+func TestListNodes(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	newService := func(store Store) *Service {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		assert.NoError(t, err)
+		s, err := NewService(zap.NewExample(), ln, nil, store)
+		assert.NoError(t, err)
+		err = s.Start()
+		assert.NoError(t, err)
+		return s
+	}
+	leaderStore := mocks.NewMockStore(ctl)
+	leader := newService(leaderStore)
+	defer leader.Stop(context.Background())
+	followerStore := mocks.NewMockStore(ctl)
+	follower := newService(followerStore)
+	defer follower.Stop(context.Background())
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	unreachable := ln.Addr().String()
+	ln.Close()
+
+	// the request is redirected to the leader.
+	followerStore.EXPECT().Leader().Return(false, leader.Addr()).Times(2)
+	leaderStore.EXPECT().Leader().Return(true, leader.Addr())
+	leaderStore.EXPECT().Members().Return([]*command.Member{
+		{Id: "node-1", Address: leader.Addr(), Suffrage: RoleVoter, Leader: true},
+		{Id: "node-2", Address: follower.Addr(), Suffrage: RoleNonvoter},
+		{Id: "node-3", Address: unreachable, Suffrage: RoleVoter},
+	}, nil)
+	leaderStore.EXPECT().NodeStatus().Return(&command.NodeStatus{Id: "node-1", State: "leader", AppliedIndex: 10, LastIndex: 10})
+	followerStore.EXPECT().NodeStatus().Return(&command.NodeStatus{Id: "node-2", State: "follower", AppliedIndex: 7, LastIndex: 8, LastContact: 15})
+
+	resp, err := follower.DoListNodesRequest(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(10), resp.LastIndex)
+	assert.Len(t, resp.Members, 3)
+
+	assert.True(t, resp.Members[0].Leader)
+	assert.Equal(t, uint64(10), resp.Members[0].AppliedIndex)
+	assert.Equal(t, uint64(0), resp.Members[0].Lag)
+
+	assert.Equal(t, RoleNonvoter, resp.Members[1].Suffrage)
+	assert.Equal(t, uint64(7), resp.Members[1].AppliedIndex)
+	assert.Equal(t, uint64(3), resp.Members[1].Lag)
+	assert.Equal(t, int64(15), resp.Members[1].LastContact)
+	assert.Empty(t, resp.Members[1].Error)
+
+	assert.NotEmpty(t, resp.Members[2].Error)
+}
+
+func TestBarrier(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	store := mocks.NewMockStore(ctl)
+
+	ts := httptest.NewUnstartedServer(nil)
+	ts.EnableHTTP2 = true
+	ts.StartTLS()
+	defer ts.Close()
+
+	ln, err := tls.Listen("tcp", "127.0.0.1:0", ts.TLS)
+	assert.NoError(t, err)
+	s, err := NewService(zap.NewExample(), ln, ts.TLS, store)
+	assert.NoError(t, err)
+
+	err = s.Start()
+	assert.NoError(t, err)
+	defer s.Stop(context.Background())
+
+	store.EXPECT().Barrier(gomock.Any()).DoAndReturn(func(ctx context.Context) (uint64, error) {
+		_, ok := ctx.Deadline()
+		assert.True(t, ok)
+		return uint64(10), nil
+	})
+
+	r, err := http.NewRequest(http.MethodGet, fmt.Sprintf("https://%s/reads/barrier", s.Addr()), nil)
+	assert.NoError(t, err)
+	r.Header.Set(RequestTimeoutHeader, "1s")
+
+	resp, err := ts.Client().Do(r)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	b, err := ioutil.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	var barrier command.BarrierResponse
+	err = jsoniter.Unmarshal(b, &barrier)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(10), barrier.Index)
 }
 
 func GetTLSConfig() (*tls.Config, error) {
@@ -0,0 +1,64 @@
+package hraftdispatcher
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/casbin/hraft-dispatcher/command"
+)
+
+// SchedulePoliciesContext adds a set of rules that are added to the policies by the cluster at activateAt.
+// If expiresAt is not the zero time, the rules are removed at expiresAt after they are activated.
+// A rule that is already pending is rescheduled.
+func (h *HRaftDispatcher) SchedulePoliciesContext(ctx context.Context, sec string, pType string, rules [][]string, activateAt, expiresAt time.Time) (*command.ApplyResponse, error) {
+	if activateAt.IsZero() {
+		return nil, errors.New("the activation time is not provided")
+	}
+	if !expiresAt.IsZero() && !expiresAt.After(activateAt) {
+		return nil, errors.New("the expiration time must be after the activation time")
+	}
+
+	request := &command.SchedulePoliciesRequest{
+		Sec:        sec,
+		PType:      pType,
+		Rules:      newStringArrays(rules),
+		ActivateAt: activateAt.Unix(),
+	}
+	if !expiresAt.IsZero() {
+		request.ExpiresAt = expiresAt.Unix()
+	}
+	return h.handleApplyResponse(h.httpService.DoSchedulePoliciesRequest(ctx, request))
+}
+
+// ReschedulePoliciesContext changes the activation time of a set of pending rules.
+// The EffectedRules of the response hold the rules rescheduled.
+func (h *HRaftDispatcher) ReschedulePoliciesContext(ctx context.Context, sec string, pType string, rules [][]string, activateAt time.Time) (*command.ApplyResponse, error) {
+	if activateAt.IsZero() {
+		return nil, errors.New("the activation time is not provided")
+	}
+
+	request := &command.ReschedulePoliciesRequest{
+		Sec:        sec,
+		PType:      pType,
+		Rules:      newStringArrays(rules),
+		ActivateAt: activateAt.Unix(),
+	}
+	return h.handleApplyResponse(h.httpService.DoReschedulePoliciesRequest(ctx, request))
+}
+
+// CancelScheduledPoliciesContext removes a set of pending rules before they are activated.
+// The EffectedRules of the response hold the rules removed.
+func (h *HRaftDispatcher) CancelScheduledPoliciesContext(ctx context.Context, sec string, pType string, rules [][]string) (*command.ApplyResponse, error) {
+	request := &command.CancelScheduledPoliciesRequest{
+		Sec:   sec,
+		PType: pType,
+		Rules: newStringArrays(rules),
+	}
+	return h.handleApplyResponse(h.httpService.DoCancelScheduledPoliciesRequest(ctx, request))
+}
+
+// PendingPolicies returns the rules waiting to be activated on the current node.
+func (h *HRaftDispatcher) PendingPolicies() ([]*command.PendingPolicy, error) {
+	return h.store.PendingPolicies()
+}
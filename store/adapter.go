@@ -0,0 +1,163 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/casbin/casbin/v2/model"
+	"github.com/casbin/casbin/v2/persist"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// ErrReadOnlyAdapter is returned by the write methods of Adapter,
+// the policies are changed through the dispatcher instead.
+var ErrReadOnlyAdapter = errors.New("the adapter is read-only")
+
+var _ persist.FilteredAdapter = &Adapter{}
+
+// Filter selects the rules loaded by Adapter.LoadFilteredPolicy.
+type Filter struct {
+	// PTypes limits the rules to the given policy types, all types are loaded if it is empty.
+	PTypes []string
+	// FieldIndex and FieldValues match the rules like RemoveFilteredPolicy, an empty value matches any value.
+	FieldIndex  int
+	FieldValues []string
+}
+
+// match reports whether the rule is selected by the filter.
+func (f *Filter) match(rule Rule) bool {
+	if len(f.PTypes) != 0 {
+		found := false
+		for _, pType := range f.PTypes {
+			if pType == rule.PType {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	for i, value := range f.FieldValues {
+		if len(value) == 0 {
+			continue
+		}
+		index := f.FieldIndex + i
+		if index >= len(rule.Rule) || rule.Rule[index] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// Adapter is a read-only persist.FilteredAdapter that loads the rules from the database replicated to the current node.
+// The rules are read from a snapshot of the database, a write applied while the enforcer is loading the policies
+// may be missed by the enforcer until the next load.
+type Adapter struct {
+	p        *PolicyOperator
+	filtered bool
+}
+
+// NewAdapter returns an Adapter reading from the database of the given PolicyOperator.
+func NewAdapter(p *PolicyOperator) *Adapter {
+	return &Adapter{p: p}
+}
+
+// LoadPolicy implements the persist.Adapter interface.
+func (a *Adapter) LoadPolicy(m model.Model) error {
+	err := a.p.forEachRule(func(rule Rule) error {
+		return addRuleToModel(m, rule)
+	})
+	if err != nil {
+		return err
+	}
+	a.filtered = false
+	return nil
+}
+
+// LoadFilteredPolicy implements the persist.FilteredAdapter interface.
+// The filter is a Filter or a *Filter, a nil filter loads all rules.
+func (a *Adapter) LoadFilteredPolicy(m model.Model, filter interface{}) error {
+	var f *Filter
+	switch v := filter.(type) {
+	case nil:
+		return a.LoadPolicy(m)
+	case Filter:
+		f = &v
+	case *Filter:
+		if v == nil {
+			return a.LoadPolicy(m)
+		}
+		f = v
+	default:
+		return fmt.Errorf("invalid filter type: %T", filter)
+	}
+
+	err := a.p.forEachRule(func(rule Rule) error {
+		if !f.match(rule) {
+			return nil
+		}
+		return addRuleToModel(m, rule)
+	})
+	if err != nil {
+		return err
+	}
+	a.filtered = true
+	return nil
+}
+
+// IsFiltered implements the persist.FilteredAdapter interface.
+func (a *Adapter) IsFiltered() bool {
+	return a.filtered
+}
+
+// SavePolicy implements the persist.Adapter interface, it always returns ErrReadOnlyAdapter.
+func (a *Adapter) SavePolicy(model.Model) error {
+	return ErrReadOnlyAdapter
+}
+
+// AddPolicy implements the persist.Adapter interface, it always returns ErrReadOnlyAdapter.
+func (a *Adapter) AddPolicy(string, string, []string) error {
+	return ErrReadOnlyAdapter
+}
+
+// RemovePolicy implements the persist.Adapter interface, it always returns ErrReadOnlyAdapter.
+func (a *Adapter) RemovePolicy(string, string, []string) error {
+	return ErrReadOnlyAdapter
+}
+
+// RemoveFilteredPolicy implements the persist.Adapter interface, it always returns ErrReadOnlyAdapter.
+func (a *Adapter) RemoveFilteredPolicy(string, string, int, ...string) error {
+	return ErrReadOnlyAdapter
+}
+
+// addRuleToModel adds a rule to the model, the model must define the policy type of the rule.
+func addRuleToModel(m model.Model, rule Rule) error {
+	if _, ok := m[rule.Sec][rule.PType]; !ok {
+		return fmt.Errorf("the policy type %s is not defined in the model", rule.PType)
+	}
+	m.AddPolicy(rule.Sec, rule.PType, rule.Rule)
+	return nil
+}
+
+// forEachRule calls fn for each rule in the database within a read-only bolt transaction.
+// The lock is only held to begin the transaction, so that fn can wait for the enforcer without blocking Apply.
+func (p *PolicyOperator) forEachRule(fn func(rule Rule) error) error {
+	p.l.Lock()
+	tx, err := p.db.Begin(false)
+	p.l.Unlock()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	return tx.Bucket(policyBucketName).ForEach(func(k, v []byte) error {
+		var rule Rule
+		err := jsoniter.Unmarshal(k, &rule)
+		if err != nil {
+			return err
+		}
+		return fn(rule)
+	})
+}
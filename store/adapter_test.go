@@ -0,0 +1,76 @@
+package store
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/casbin/casbin/v2/model"
+	"go.uber.org/zap"
+
+	"github.com/casbin/hraft-dispatcher/store/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+const testModelText = `
+[request_definition]
+r = sub, obj, act
+
+[policy_definition]
+p = sub, obj, act
+
+[role_definition]
+g = _, _
+
+[policy_effect]
+e = some(where (p.eft == allow))
+
+[matchers]
+m = g(r.sub, p.sub) && r.obj == p.obj && r.act == p.act
+`
+
+func TestAdapter_LoadPolicy(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	e := mocks.NewMockIDistributedEnforcer(ctl)
+
+	dir, err := ioutil.TempDir("", "casbin-hraft-")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	p, err := NewPolicyOperator(zap.NewExample(), dir, e)
+	assert.NoError(t, err)
+
+	policies := [][]string{{"role:admin", "/", "*"}, {"role:user", "/", "GET"}}
+	e.EXPECT().AddPoliciesSelf(nil, "p", "p", policies).Return(policies, nil)
+	_, err = p.AddPolicies("p", "p", policies)
+	assert.NoError(t, err)
+	e.EXPECT().AddPoliciesSelf(nil, "g", "g", [][]string{{"alice", "role:admin"}}).Return([][]string{{"alice", "role:admin"}}, nil)
+	_, err = p.AddPolicies("g", "g", [][]string{{"alice", "role:admin"}})
+	assert.NoError(t, err)
+
+	m, err := model.NewModelFromString(testModelText)
+	assert.NoError(t, err)
+
+	a := NewAdapter(p)
+	err = a.LoadPolicy(m)
+	assert.NoError(t, err)
+	assert.False(t, a.IsFiltered())
+	assert.ElementsMatch(t, policies, m.GetPolicy("p", "p"))
+	assert.Equal(t, [][]string{{"alice", "role:admin"}}, m.GetPolicy("g", "g"))
+
+	m.ClearPolicy()
+	err = a.LoadFilteredPolicy(m, &Filter{PTypes: []string{"p"}, FieldIndex: 2, FieldValues: []string{"GET"}})
+	assert.NoError(t, err)
+	assert.True(t, a.IsFiltered())
+	assert.Equal(t, [][]string{{"role:user", "/", "GET"}}, m.GetPolicy("p", "p"))
+	assert.Empty(t, m.GetPolicy("g", "g"))
+
+	err = a.LoadFilteredPolicy(m, "p")
+	assert.Error(t, err)
+
+	assert.Equal(t, ErrReadOnlyAdapter, a.SavePolicy(m))
+	assert.Equal(t, ErrReadOnlyAdapter, a.AddPolicy("p", "p", []string{"role:guest", "/", "GET"}))
+}
@@ -3,6 +3,7 @@ package store
 import (
 	"bytes"
 	"compress/gzip"
+	"encoding/binary"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -20,10 +21,26 @@ import (
 
 const (
 	databaseFilename = "casbin.db"
+	// defaultRequestLimit is the number of the request IDs kept for deduplication.
+	defaultRequestLimit = 10000
 )
 
 var (
 	policyBucketName = []byte("policy_rules")
+	metaBucketName   = []byte("meta")
+	revisionKey      = []byte("revision")
+	// bootstrappedKey is set once the cluster is seeded with the initial policies.
+	bootstrappedKey = []byte("bootstrapped")
+	// changeIndexKey holds the index of the last raft log that changed the policies.
+	changeIndexKey = []byte("change_index")
+	// appliedIndexKey holds the index of the last raft log applied to the database.
+	appliedIndexKey = []byte("applied_index")
+	// requestBucketName holds the responses of the applied commands by request ID.
+	requestBucketName = []byte("requests")
+	// requestLogBucketName holds the request IDs in the order they are applied.
+	requestLogBucketName = []byte("request_log")
+	// expirationBucketName holds the expiration time of the rules in unix seconds.
+	expirationBucketName = []byte("policy_expirations")
 )
 
 // PolicyOperator is used to update policies and provide persistence.
@@ -32,20 +49,47 @@ type PolicyOperator struct {
 	db       *bolt.DB
 	l        *sync.Mutex
 	logger   *zap.Logger
+	// revision is increased by every write that changes the policies.
+	revision uint64
+	// requestLimit is the number of the request IDs kept for deduplication.
+	requestLimit uint64
+	// index is the index of the raft log being applied.
+	index uint64
+	// appliedIndex is the index of the last raft log applied to the database.
+	appliedIndex uint64
+	// changes holds the changes of the rules not taken by TakeChanges yet.
+	changes []change
 }
 
 // NewPolicyOperator returns a PolicyOperator.
 func NewPolicyOperator(logger *zap.Logger, path string, e casbin.IDistributedEnforcer) (*PolicyOperator, error) {
 	p := &PolicyOperator{
-		enforcer: e,
-		l:        &sync.Mutex{},
-		logger:   logger,
+		enforcer:     e,
+		l:            &sync.Mutex{},
+		logger:       logger,
+		requestLimit: defaultRequestLimit,
 	}
 	dbPath := filepath.Join(path, databaseFilename)
 	if err := p.openDBFile(dbPath); err != nil {
 		return nil, errors.Wrapf(err, "failed to open bolt file")
 	}
 
+	// the raft logs applied to the database are skipped when raft replays them after a restart,
+	// and so is a replayed bootstrap command once the cluster is bootstrapped,
+	// so the enforcer starts with the policies in the database.
+	var empty bool
+	err := p.db.View(func(tx *bolt.Tx) error {
+		k, _ := tx.Bucket(policyBucketName).Cursor().First()
+		empty = k == nil
+		return nil
+	})
+	if err == nil && !empty {
+		err = p.loadPolicy()
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load policy from bolt")
+	}
+
 	return p, nil
 }
 
@@ -62,7 +106,94 @@ func (p *PolicyOperator) openDBFile(dbPath string) error {
 
 	p.db = boltDB
 
-	return p.createBucket(policyBucketName)
+	err = p.createBucket(policyBucketName)
+	if err != nil {
+		return err
+	}
+	err = p.createBucket(metaBucketName)
+	if err != nil {
+		return err
+	}
+	err = p.createBucket(requestBucketName)
+	if err != nil {
+		return err
+	}
+	err = p.createBucket(requestLogBucketName)
+	if err != nil {
+		return err
+	}
+	err = p.createBucket(expirationBucketName)
+	if err != nil {
+		return err
+	}
+	err = p.createBucket(pendingBucketName)
+	if err != nil {
+		return err
+	}
+
+	return p.db.View(func(tx *bolt.Tx) error {
+		p.revision, p.appliedIndex = 0, 0
+		if value := tx.Bucket(metaBucketName).Get(appliedIndexKey); value != nil {
+			p.appliedIndex, err = strconv.ParseUint(string(value), 10, 64)
+			if err != nil {
+				return err
+			}
+		}
+		value := tx.Bucket(metaBucketName).Get(revisionKey)
+		if value == nil {
+			return nil
+		}
+		p.revision, err = strconv.ParseUint(string(value), 10, 64)
+		return err
+	})
+}
+
+// Revision returns the revision of the policies.
+func (p *PolicyOperator) Revision() uint64 {
+	p.l.Lock()
+	defer p.l.Unlock()
+
+	return p.revision
+}
+
+// AppliedIndex returns the index of the last raft log applied to the database, 0 if it is unknown.
+func (p *PolicyOperator) AppliedIndex() uint64 {
+	p.l.Lock()
+	defer p.l.Unlock()
+
+	return p.appliedIndex
+}
+
+// LoadRequest returns the response saved for the given request ID, or nil if the request ID is unknown.
+func (p *PolicyOperator) LoadRequest(id string) ([]byte, error) {
+	p.l.Lock()
+	defer p.l.Unlock()
+
+	var value []byte
+	err := p.db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket(requestBucketName).Get([]byte(id))
+		if v != nil {
+			value = append([]byte(nil), v...)
+		}
+		return nil
+	})
+	return value, err
+}
+
+// SaveRequest saves the response of the given request ID.
+// Only the latest requestLimit request IDs are kept, the oldest one is removed when the limit is exceeded.
+func (p *PolicyOperator) SaveRequest(id string, value []byte) error {
+	return p.update(func(t *PolicyTx) error {
+		return t.SaveRequest(id, value)
+	})
+}
+
+// Close closes the database.
+func (p *PolicyOperator) Close() error {
+	p.l.Lock()
+	defer p.l.Unlock()
+
+	return p.db.Close()
 }
 
 // Restore is used to restore a database from io.ReadCloser.
@@ -112,6 +243,8 @@ func (p *PolicyOperator) Restore(rc io.ReadCloser) error {
 		p.logger.Error("failed to load policy from bolt", zap.Error(err))
 		return errors.Wrapf(err, "failed to load policy from bolt")
 	}
+	// the changes before the snapshot are replaced by the restored rules.
+	p.changes = nil
 	return nil
 }
 
@@ -181,284 +314,549 @@ func (p *PolicyOperator) loadPolicy() error {
 	return err
 }
 
-// AddPolicies adds a set of rules.
-func (p *PolicyOperator) AddPolicies(sec, pType string, rules [][]string) error {
-	p.l.Lock()
-	defer p.l.Unlock()
+// PolicyTx performs the operations of a transaction,
+// the rules are updated in the enforcer and in the database within the same bolt transaction.
+type PolicyTx struct {
+	p  *PolicyOperator
+	tx *bolt.Tx
+	// changed reports whether the policies are changed by the transaction.
+	changed bool
+	// changes holds the changes of the rules made by the transaction.
+	changes []change
+}
 
-	effected, err := p.enforcer.AddPoliciesSelf(nil, sec, pType, rules)
+// SaveRequest saves the response of the given request ID.
+// Only the latest requestLimit request IDs are kept, the oldest one is removed when the limit is exceeded.
+func (t *PolicyTx) SaveRequest(id string, value []byte) error {
+	requests := t.tx.Bucket(requestBucketName)
+	requestLog := t.tx.Bucket(requestLogBucketName)
+
+	seq, err := requestLog.NextSequence()
 	if err != nil {
 		return err
 	}
-	if len(effected) == 0 {
-		return nil
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, seq)
+	err = requestLog.Put(key, []byte(id))
+	if err != nil {
+		return err
+	}
+	err = requests.Put([]byte(id), value)
+	if err != nil {
+		return err
 	}
 
-	err = p.db.Update(func(tx *bolt.Tx) error {
-		bkt := tx.Bucket(policyBucketName)
-		for _, item := range rules {
-			key, err := newRuleBytes(sec, pType, item)
-			if err != nil {
-				return err
-			}
+	// the sequences in the log are contiguous, because the oldest one is always removed first.
+	var expired [][]byte
+	c := requestLog.Cursor()
+	for k, _ := c.First(); k != nil && seq-binary.BigEndian.Uint64(k)+1 > t.p.requestLimit; k, _ = c.Next() {
+		expired = append(expired, append([]byte(nil), k...))
+	}
+	for _, k := range expired {
+		err = requests.Delete(requestLog.Get(k))
+		if err != nil {
+			return err
+		}
+		err = requestLog.Delete(k)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-			value, err := bkt.NextSequence()
-			if err != nil {
-				return err
-			}
+// Revision returns the revision of the policies once the transaction is committed.
+func (t *PolicyTx) Revision() uint64 {
+	if t.changed {
+		return t.p.revision + 1
+	}
+	return t.p.revision
+}
+
+// record records a change of the rules made by the transaction.
+func (t *PolicyTx) record(typ changeType, sec, pType string, rules [][]string) {
+	t.changed = true
+	t.changes = append(t.changes, change{Type: typ, Sec: sec, PType: pType, Rules: rules})
+}
+
+// update calls fn within a writable bolt transaction.
+func (p *PolicyOperator) update(fn func(t *PolicyTx) error) error {
+	p.l.Lock()
+	defer p.l.Unlock()
 
-			err = bkt.Put(key, []byte(strconv.FormatUint(value, 10)))
+	return p.updateLocked(fn)
+}
+
+// updateLocked calls fn within a writable bolt transaction, and increases the revision if fn changes the policies.
+// The caller must hold p.l.
+func (p *PolicyOperator) updateLocked(fn func(t *PolicyTx) error) error {
+	t := &PolicyTx{p: p}
+	err := p.db.Update(func(tx *bolt.Tx) error {
+		t.tx = tx
+		err := fn(t)
+		if err != nil || !t.changed {
+			return err
+		}
+		if p.index != 0 {
+			err = tx.Bucket(metaBucketName).Put(changeIndexKey, []byte(strconv.FormatUint(p.index, 10)))
 			if err != nil {
 				return err
 			}
 		}
-		return nil
+		return tx.Bucket(metaBucketName).Put(revisionKey, []byte(strconv.FormatUint(p.revision+1, 10)))
 	})
-	if err != nil {
-		p.logger.Error("failed to persist to database", zap.Error(err))
+	if err == nil && t.changed {
+		p.revision++
+		p.changes = append(p.changes, t.changes...)
 	}
-
 	return err
 }
 
-// RemovePolicies removes a set of rules.
-func (p *PolicyOperator) RemovePolicies(sec, pType string, rules [][]string) error {
+// applyLog calls fn within a writable bolt transaction to apply the raft log at the given index,
+// the index is saved as the applied index by the same transaction, so the log is applied to the database only once.
+// If fn returns an error, the bolt transaction is rolled back,
+// and the policies of the enforcer are reloaded from the database if fn changed them.
+func (p *PolicyOperator) applyLog(index uint64, fn func(t *PolicyTx) error) error {
 	p.l.Lock()
 	defer p.l.Unlock()
 
-	effected, err := p.enforcer.RemovePoliciesSelf(nil, sec, pType, rules)
+	p.index = index
+	var changed bool
+	err := p.updateLocked(func(t *PolicyTx) error {
+		err := fn(t)
+		if err != nil {
+			changed = t.changed
+			return err
+		}
+		return t.tx.Bucket(metaBucketName).Put(appliedIndexKey, []byte(strconv.FormatUint(index, 10)))
+	})
 	if err != nil {
-		p.logger.Error("failed to call RemovePolicySelf", zap.Error(err))
+		if changed {
+			p.logger.Error("failed to apply the log, rolling back", zap.Uint64("index", index), zap.Error(err))
+			if loadErr := p.loadPolicy(); loadErr != nil {
+				return errors.Wrapf(loadErr, "failed to roll back the log: %s", err)
+			}
+		}
 		return err
 	}
-	if len(effected) == 0 {
-		return nil
-	}
+	p.appliedIndex = index
+	return nil
+}
 
-	err = p.db.Update(func(tx *bolt.Tx) error {
-		bkt := tx.Bucket(policyBucketName)
-		for _, item := range rules {
-			key, err := newRuleBytes(sec, pType, item)
-			if err != nil {
-				return err
-			}
+// Transaction calls fn within a writable bolt transaction, all the operations performed by fn are applied atomically.
+// If fn returns an error, the bolt transaction is rolled back, and the policies of the enforcer are reloaded from the database.
+func (p *PolicyOperator) Transaction(fn func(t *PolicyTx) error) error {
+	p.l.Lock()
+	defer p.l.Unlock()
 
-			err = bkt.Delete(key)
-			if err != nil {
-				return err
-			}
+	err := p.updateLocked(fn)
+	if err != nil {
+		p.logger.Error("failed to apply the transaction, rolling back", zap.Error(err))
+		if loadErr := p.loadPolicy(); loadErr != nil {
+			return errors.Wrapf(loadErr, "failed to roll back the transaction: %s", err)
 		}
-		return nil
-	})
-
+	}
 	return err
 }
 
-// RemoveFilteredPolicy removes a set of rules that match a pattern.
-func (p *PolicyOperator) RemoveFilteredPolicy(sec string, pType string, fieldIndex int, fieldValues ...string) error {
+// Bootstrapped reports whether the cluster is seeded with the initial policies.
+func (p *PolicyOperator) Bootstrapped() (bool, error) {
 	p.l.Lock()
 	defer p.l.Unlock()
 
-	effected, err := p.enforcer.RemoveFilteredPolicySelf(nil, sec, pType, fieldIndex, fieldValues...)
-	if err != nil {
-		p.logger.Error("failed to call RemoveFilteredPolicySelf", zap.Error(err))
+	var bootstrapped bool
+	err := p.db.View(func(tx *bolt.Tx) error {
+		bootstrapped = tx.Bucket(metaBucketName).Get(bootstrappedKey) != nil
+		return nil
+	})
+	return bootstrapped, err
+}
+
+// AddPolicies adds a set of rules, and returns the rules actually added.
+func (p *PolicyOperator) AddPolicies(sec, pType string, rules [][]string) ([][]string, error) {
+	return p.AddExpiringPolicies(sec, pType, rules, nil)
+}
+
+// AddExpiringPolicies adds a set of rules that expire at the given unix time, and returns the rules actually added.
+func (p *PolicyOperator) AddExpiringPolicies(sec, pType string, rules [][]string, expiresAt []int64) ([][]string, error) {
+	var effected [][]string
+	err := p.update(func(t *PolicyTx) error {
+		var err error
+		effected, err = t.AddExpiringPolicies(sec, pType, rules, expiresAt)
+		return err
+	})
+	return effected, err
+}
+
+// RemovePolicies removes a set of rules, and returns the rules actually removed.
+func (p *PolicyOperator) RemovePolicies(sec, pType string, rules [][]string) ([][]string, error) {
+	var effected [][]string
+	err := p.update(func(t *PolicyTx) error {
+		var err error
+		effected, err = t.RemovePolicies(sec, pType, rules)
+		return err
+	})
+	return effected, err
+}
+
+// RemoveFilteredPolicy removes a set of rules that match a pattern, and returns the rules actually removed.
+func (p *PolicyOperator) RemoveFilteredPolicy(sec string, pType string, fieldIndex int, fieldValues ...string) ([][]string, error) {
+	var effected [][]string
+	err := p.update(func(t *PolicyTx) error {
+		var err error
+		effected, err = t.RemoveFilteredPolicy(sec, pType, fieldIndex, fieldValues...)
+		return err
+	})
+	return effected, err
+}
+
+//UpdatePolicy replaces an existing rule, and reports whether the rule is replaced.
+func (p *PolicyOperator) UpdatePolicy(sec, pType string, oldRule, newRule []string) (bool, error) {
+	var effected bool
+	err := p.update(func(t *PolicyTx) error {
+		var err error
+		effected, err = t.UpdatePolicy(sec, pType, oldRule, newRule)
+		return err
+	})
+	return effected, err
+}
+
+//UpdatePolicies replaces a set of existing rule, and reports whether the rules are replaced.
+func (p *PolicyOperator) UpdatePolicies(sec, pType string, oldRules, newRules [][]string) (bool, error) {
+	var effected bool
+	err := p.update(func(t *PolicyTx) error {
+		var err error
+		effected, err = t.UpdatePolicies(sec, pType, oldRules, newRules)
 		return err
+	})
+	return effected, err
+}
+
+//UpdateFilteredPolicies replaces a set of existing rule, and reports whether the rules are replaced.
+func (p *PolicyOperator) UpdateFilteredPolicies(sec, pType string, oldRules, newRules [][]string) (bool, error) {
+	var effected bool
+	err := p.update(func(t *PolicyTx) error {
+		var err error
+		effected, err = t.UpdateFilteredPolicies(sec, pType, oldRules, newRules)
+		return err
+	})
+	return effected, err
+}
+
+// ClearPolicy clears all rules.
+func (p *PolicyOperator) ClearPolicy() error {
+	return p.update(func(t *PolicyTx) error {
+		return t.ClearPolicy()
+	})
+}
+
+// AddPolicies adds a set of rules, and returns the rules actually added.
+func (t *PolicyTx) AddPolicies(sec, pType string, rules [][]string) ([][]string, error) {
+	return t.AddExpiringPolicies(sec, pType, rules, nil)
+}
+
+// AddExpiringPolicies adds a set of rules that expire at the given unix time, and returns the rules actually added.
+// expiresAt is either empty or has the same length as rules, a zero time means the rule never expires.
+func (t *PolicyTx) AddExpiringPolicies(sec, pType string, rules [][]string, expiresAt []int64) ([][]string, error) {
+	if len(expiresAt) != 0 && len(expiresAt) != len(rules) {
+		return nil, fmt.Errorf("the number of expiration times %d does not match the number of rules %d", len(expiresAt), len(rules))
+	}
+
+	effected, err := t.p.enforcer.AddPoliciesSelf(nil, sec, pType, rules)
+	if err != nil {
+		return nil, err
 	}
 	if len(effected) == 0 {
-		return nil
+		return nil, nil
 	}
 
-	err = p.db.Update(func(tx *bolt.Tx) error {
-		bkt := tx.Bucket(policyBucketName)
-		for _, item := range effected {
-			key, err := newRuleBytes(sec, pType, item)
-			if err != nil {
-				return err
-			}
-
-			err = bkt.Delete(key)
-			if err != nil {
-				return err
-			}
-		}
-		return nil
-	})
+	err = putRules(t.tx, sec, pType, effected)
+	if err == nil && len(expiresAt) != 0 {
+		err = putExpirations(t.tx, sec, pType, rules, expiresAt, effected)
+	}
 	if err != nil {
-		p.logger.Error("failed to persist to database", zap.Error(err))
+		t.p.logger.Error("failed to persist to database", zap.Error(err))
+		return nil, err
 	}
 
-	return err
+	t.record(changeAdd, sec, pType, effected)
+	return effected, nil
 }
 
-//UpdatePolicy replaces an existing rule.
-func (p *PolicyOperator) UpdatePolicy(sec, pType string, oldRule, newRule []string) error {
-	p.l.Lock()
-	defer p.l.Unlock()
-
-	effected, err := p.enforcer.UpdatePolicySelf(nil, sec, pType, oldRule, newRule)
+// RemovePolicies removes a set of rules, and returns the rules actually removed.
+func (t *PolicyTx) RemovePolicies(sec, pType string, rules [][]string) ([][]string, error) {
+	effected, err := t.p.enforcer.RemovePoliciesSelf(nil, sec, pType, rules)
 	if err != nil {
-		p.logger.Error("failed to call UpdatePolicySelf", zap.Error(err))
-		return err
+		t.p.logger.Error("failed to call RemovePolicySelf", zap.Error(err))
+		return nil, err
 	}
-	if effected == false {
-		return nil
+	if len(effected) == 0 {
+		return nil, nil
 	}
 
-	err = p.db.Update(func(tx *bolt.Tx) error {
-		bkt := tx.Bucket(policyBucketName)
+	err = deleteRules(t.tx, sec, pType, effected)
+	if err != nil {
+		t.p.logger.Error("failed to persist to database", zap.Error(err))
+		return nil, err
+	}
 
-		newKey, err := newRuleBytes(sec, pType, newRule)
-		if err != nil {
-			return err
-		}
-		value, err := bkt.NextSequence()
-		if err != nil {
-			return err
-		}
+	t.record(changeRemove, sec, pType, effected)
+	return effected, nil
+}
 
-		err = bkt.Put(newKey, []byte(strconv.FormatUint(value, 10)))
-		if err != nil {
-			return err
-		}
+// RemoveFilteredPolicy removes a set of rules that match a pattern, and returns the rules actually removed.
+func (t *PolicyTx) RemoveFilteredPolicy(sec string, pType string, fieldIndex int, fieldValues ...string) ([][]string, error) {
+	effected, err := t.p.enforcer.RemoveFilteredPolicySelf(nil, sec, pType, fieldIndex, fieldValues...)
+	if err != nil {
+		t.p.logger.Error("failed to call RemoveFilteredPolicySelf", zap.Error(err))
+		return nil, err
+	}
+	if len(effected) == 0 {
+		return nil, nil
+	}
 
-		oldKey, err := newRuleBytes(sec, pType, oldRule)
-		if err != nil {
-			return err
-		}
-		return bkt.Delete(oldKey)
-	})
+	err = deleteRules(t.tx, sec, pType, effected)
 	if err != nil {
-		p.logger.Error("failed to persist to database", zap.Error(err))
+		t.p.logger.Error("failed to persist to database", zap.Error(err))
+		return nil, err
 	}
 
-	return err
+	t.record(changeRemove, sec, pType, effected)
+	return effected, nil
 }
 
-//UpdatePolicies replaces a set of existing rule.
-func (p *PolicyOperator) UpdatePolicies(sec, pType string, oldRules, newRules [][]string) error {
-	p.l.Lock()
-	defer p.l.Unlock()
-
-	effected, err := p.enforcer.UpdatePoliciesSelf(nil, sec, pType, oldRules, newRules)
+//UpdatePolicy replaces an existing rule, and reports whether the rule is replaced.
+func (t *PolicyTx) UpdatePolicy(sec, pType string, oldRule, newRule []string) (bool, error) {
+	effected, err := t.p.enforcer.UpdatePolicySelf(nil, sec, pType, oldRule, newRule)
 	if err != nil {
-		p.logger.Error("failed to call UpdatePoliciesSelf", zap.Error(err))
-		return err
+		t.p.logger.Error("failed to call UpdatePolicySelf", zap.Error(err))
+		return false, err
 	}
 	if effected == false {
-		return nil
+		return false, nil
 	}
 
-	err = p.db.Update(func(tx *bolt.Tx) error {
-		bkt := tx.Bucket(policyBucketName)
+	err = replaceRules(t.tx, sec, pType, [][]string{oldRule}, [][]string{newRule})
+	if err != nil {
+		t.p.logger.Error("failed to persist to database", zap.Error(err))
+		return false, err
+	}
 
-		for _, newRule := range newRules {
-			newKey, err := newRuleBytes(sec, pType, newRule)
-			if err != nil {
-				return err
-			}
-			value, err := bkt.NextSequence()
-			if err != nil {
-				return err
-			}
+	t.record(changeRemove, sec, pType, [][]string{oldRule})
+	t.record(changeAdd, sec, pType, [][]string{newRule})
+	return true, nil
+}
 
-			err = bkt.Put(newKey, []byte(strconv.FormatUint(value, 10)))
-			if err != nil {
-				return err
-			}
-		}
+//UpdatePolicies replaces a set of existing rule, and reports whether the rules are replaced.
+func (t *PolicyTx) UpdatePolicies(sec, pType string, oldRules, newRules [][]string) (bool, error) {
+	effected, err := t.p.enforcer.UpdatePoliciesSelf(nil, sec, pType, oldRules, newRules)
+	if err != nil {
+		t.p.logger.Error("failed to call UpdatePoliciesSelf", zap.Error(err))
+		return false, err
+	}
+	if effected == false {
+		return false, nil
+	}
 
-		for _, oldRule := range oldRules {
-			oldKey, err := newRuleBytes(sec, pType, oldRule)
-			if err != nil {
-				return err
-			}
-			return bkt.Delete(oldKey)
-		}
-		return nil
-	})
+	err = replaceRules(t.tx, sec, pType, oldRules, newRules)
 	if err != nil {
-		p.logger.Error("failed to persist to database", zap.Error(err))
+		t.p.logger.Error("failed to persist to database", zap.Error(err))
+		return false, err
 	}
 
-	return nil
+	t.record(changeRemove, sec, pType, oldRules)
+	t.record(changeAdd, sec, pType, newRules)
+	return true, nil
 }
 
-//UpdateFilteredPolicies replaces a set of existing rule.
-func (p *PolicyOperator) UpdateFilteredPolicies(sec, pType string, oldRules, newRules [][]string) error {
-	p.l.Lock()
-	defer p.l.Unlock()
+//UpdateFilteredPolicies replaces a set of existing rule, and reports whether the rules are replaced.
+func (t *PolicyTx) UpdateFilteredPolicies(sec, pType string, oldRules, newRules [][]string) (bool, error) {
+	return t.UpdatePolicies(sec, pType, oldRules, newRules)
+}
 
-	effected, err := p.enforcer.UpdatePoliciesSelf(nil, sec, pType, oldRules, newRules)
+// Bootstrapped reports whether the cluster is seeded with the initial policies.
+func (t *PolicyTx) Bootstrapped() bool {
+	return t.tx.Bucket(metaBucketName).Get(bootstrappedKey) != nil
+}
+
+// SetBootstrapped records that the cluster is seeded with the initial policies.
+func (t *PolicyTx) SetBootstrapped() error {
+	return t.tx.Bucket(metaBucketName).Put(bootstrappedKey, []byte("1"))
+}
+
+// ClearPolicy clears all rules, including the pending ones.
+func (t *PolicyTx) ClearPolicy() error {
+	err := t.p.enforcer.ClearPolicySelf(nil)
 	if err != nil {
-		p.logger.Error("failed to call UpdatePoliciesSelf", zap.Error(err))
+		t.p.logger.Error("failed to call ClearPolicySelf", zap.Error(err))
 		return err
 	}
-	if effected == false {
-		return nil
+
+	for _, name := range [][]byte{policyBucketName, expirationBucketName, pendingBucketName} {
+		err = t.tx.DeleteBucket(name)
+		if err == nil {
+			_, err = t.tx.CreateBucket(name)
+		}
+		if err != nil {
+			break
+		}
+	}
+	if err != nil {
+		t.p.logger.Error("failed to persist to database", zap.Error(err))
+		return err
 	}
 
-	err = p.db.Update(func(tx *bolt.Tx) error {
-		bkt := tx.Bucket(policyBucketName)
+	t.record(changeClear, "", "", nil)
+	return nil
+}
+
+// ExpirePolicies removes the rules expired at the given unix time, and returns the rules removed.
+func (t *PolicyTx) ExpirePolicies(now int64) ([]Rule, error) {
+	var expired [][]byte
+	c := t.tx.Bucket(expirationBucketName).Cursor()
+	for k, v := c.First(); k != nil; k, v = c.Next() {
+		expireAt, err := strconv.ParseInt(string(v), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		if expireAt <= now {
+			expired = append(expired, append([]byte(nil), k...))
+		}
+	}
+
+	var effected []Rule
+	for _, key := range expired {
+		var rule Rule
+		err := jsoniter.Unmarshal(key, &rule)
+		if err != nil {
+			return nil, err
+		}
 
-		for _, newRule := range newRules {
-			newKey, err := newRuleBytes(sec, pType, newRule)
+		rules, err := t.RemovePolicies(rule.Sec, rule.PType, [][]string{rule.Rule})
+		if err != nil {
+			return nil, err
+		}
+		if len(rules) != 0 {
+			effected = append(effected, rule)
+		}
+
+		// the rule may have been removed from the enforcer, make sure the expiration is removed.
+		err = t.tx.Bucket(expirationBucketName).Delete(key)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return effected, nil
+}
+
+// NextExpiration returns the earliest expiration time of the rules in unix seconds,
+// ok is false if no rule expires.
+func (p *PolicyOperator) NextExpiration() (next int64, ok bool, err error) {
+	p.l.Lock()
+	defer p.l.Unlock()
+
+	err = p.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(expirationBucketName).ForEach(func(k, v []byte) error {
+			expireAt, err := strconv.ParseInt(string(v), 10, 64)
 			if err != nil {
 				return err
 			}
-			value, err := bkt.NextSequence()
-			if err != nil {
-				return err
+			if !ok || expireAt < next {
+				next, ok = expireAt, true
 			}
+			return nil
+		})
+	})
+	return next, ok, err
+}
 
-			err = bkt.Put(newKey, []byte(strconv.FormatUint(value, 10)))
-			if err != nil {
-				return err
-			}
+// ExpirePolicies removes the rules expired at the given unix time, and returns the rules removed.
+func (p *PolicyOperator) ExpirePolicies(now int64) ([]Rule, error) {
+	var effected []Rule
+	err := p.update(func(t *PolicyTx) error {
+		var err error
+		effected, err = t.ExpirePolicies(now)
+		return err
+	})
+	return effected, err
+}
+
+// putRules puts a set of rules to the database.
+func putRules(tx *bolt.Tx, sec, pType string, rules [][]string) error {
+	bkt := tx.Bucket(policyBucketName)
+	for _, item := range rules {
+		key, err := newRuleBytes(sec, pType, item)
+		if err != nil {
+			return err
 		}
 
-		for _, oldRule := range oldRules {
-			oldKey, err := newRuleBytes(sec, pType, oldRule)
-			if err != nil {
-				return err
-			}
-			return bkt.Delete(oldKey)
+		value, err := bkt.NextSequence()
+		if err != nil {
+			return err
 		}
-		return nil
-	})
-	if err != nil {
-		p.logger.Error("failed to persist to database", zap.Error(err))
-	}
 
+		err = bkt.Put(key, []byte(strconv.FormatUint(value, 10)))
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
-// ClearPolicy clears all rules.
-func (p *PolicyOperator) ClearPolicy() error {
-	p.l.Lock()
-	defer p.l.Unlock()
-
-	err := p.enforcer.ClearPolicySelf(nil)
-	if err != nil {
-		p.logger.Error("failed to call ClearPolicySelf", zap.Error(err))
-		return err
+// putExpirations puts the expiration time of the effected rules to the database, the zero time is skipped.
+func putExpirations(tx *bolt.Tx, sec, pType string, rules [][]string, expiresAt []int64, effected [][]string) error {
+	effectedKeys := make(map[string]bool, len(effected))
+	for _, item := range effected {
+		key, err := newRuleBytes(sec, pType, item)
+		if err != nil {
+			return err
+		}
+		effectedKeys[string(key)] = true
 	}
 
-	err = p.db.Update(func(tx *bolt.Tx) error {
-		err := tx.DeleteBucket(policyBucketName)
+	bkt := tx.Bucket(expirationBucketName)
+	for i, item := range rules {
+		key, err := newRuleBytes(sec, pType, item)
 		if err != nil {
 			return err
 		}
-		_, err = tx.CreateBucket(policyBucketName)
+		if expiresAt[i] == 0 || !effectedKeys[string(key)] {
+			continue
+		}
+
+		err = bkt.Put(key, []byte(strconv.FormatInt(expiresAt[i], 10)))
 		if err != nil {
 			return err
 		}
-		return nil
-	})
-	if err != nil {
-		p.logger.Error("failed to persist to database", zap.Error(err))
 	}
+	return nil
+}
 
-	return err
+// deleteRules deletes a set of rules and their expiration time from the database.
+func deleteRules(tx *bolt.Tx, sec, pType string, rules [][]string) error {
+	for _, item := range rules {
+		key, err := newRuleBytes(sec, pType, item)
+		if err != nil {
+			return err
+		}
+
+		for _, name := range [][]byte{policyBucketName, expirationBucketName} {
+			err = tx.Bucket(name).Delete(key)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// replaceRules replaces a set of rules in the database.
+func replaceRules(tx *bolt.Tx, sec, pType string, oldRules, newRules [][]string) error {
+	err := deleteRules(tx, sec, pType, oldRules)
+	if err != nil {
+		return err
+	}
+	return putRules(tx, sec, pType, newRules)
 }
 
 type Rule struct {
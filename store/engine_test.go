@@ -7,6 +7,8 @@ import (
 	"path"
 	"testing"
 
+	"github.com/pkg/errors"
+	bolt "go.etcd.io/bbolt"
 	"go.uber.org/zap"
 
 	"github.com/casbin/hraft-dispatcher/store/mocks"
@@ -30,8 +32,15 @@ func TestPolicyOperator_AddPolicies(t *testing.T) {
 	assert.NoError(t, err)
 
 	e.EXPECT().AddPoliciesSelf(nil, "p", "p", [][]string{{"role:admin", "/", "*"}, {"role:user", "/", "GET"}}).Return([][]string{{"role:admin", "/", "*"}, {"role:user", "/", "GET"}}, nil)
-	err = p.AddPolicies("p", "p", [][]string{{"role:admin", "/", "*"}, {"role:user", "/", "GET"}})
+	effected, err := p.AddPolicies("p", "p", [][]string{{"role:admin", "/", "*"}, {"role:user", "/", "GET"}})
 	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"role:admin", "/", "*"}, {"role:user", "/", "GET"}}, effected)
+	assert.Equal(t, uint64(1), p.Revision())
+
+	e.EXPECT().AddPoliciesSelf(nil, "p", "p", [][]string{{"role:admin", "/", "*"}}).Return(nil, nil)
+	_, err = p.AddPolicies("p", "p", [][]string{{"role:admin", "/", "*"}})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), p.Revision())
 }
 
 func TestPolicyOperator_RemovePolicies(t *testing.T) {
@@ -48,7 +57,7 @@ func TestPolicyOperator_RemovePolicies(t *testing.T) {
 	assert.NoError(t, err)
 
 	e.EXPECT().RemovePoliciesSelf(nil, "p", "p", [][]string{{"role:admin", "/", "*"}, {"role:user", "/", "GET"}}).Return([][]string{{"role:admin", "/", "*"}, {"role:user", "/", "GET"}}, nil)
-	err = p.RemovePolicies("p", "p", [][]string{{"role:admin", "/", "*"}, {"role:user", "/", "GET"}})
+	_, err = p.RemovePolicies("p", "p", [][]string{{"role:admin", "/", "*"}, {"role:user", "/", "GET"}})
 	assert.NoError(t, err)
 }
 
@@ -66,8 +75,9 @@ func TestPolicyOperator_RemoveFilteredPolicy(t *testing.T) {
 	assert.NoError(t, err)
 
 	e.EXPECT().RemoveFilteredPolicySelf(nil, "p", "p", 0, "role:user").Return([][]string{{"role:user", "/", "GET"}}, nil)
-	err = p.RemoveFilteredPolicy("p", "p", 0, "role:user")
+	effected, err := p.RemoveFilteredPolicy("p", "p", 0, "role:user")
 	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"role:user", "/", "GET"}}, effected)
 }
 
 func TestPolicyOperator_UpdatePolicy(t *testing.T) {
@@ -84,8 +94,132 @@ func TestPolicyOperator_UpdatePolicy(t *testing.T) {
 	assert.NoError(t, err)
 
 	e.EXPECT().UpdatePolicySelf(nil, "p", "p", []string{"role:admin", "/", "*"}, []string{"role:admin", "/admin", "*"}).Return(true, nil)
-	err = p.UpdatePolicy("p", "p", []string{"role:admin", "/", "*"}, []string{"role:admin", "/admin", "*"})
+	effected, err := p.UpdatePolicy("p", "p", []string{"role:admin", "/", "*"}, []string{"role:admin", "/admin", "*"})
+	assert.NoError(t, err)
+	assert.True(t, effected)
+}
+
+func TestPolicyOperator_Transaction(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	e := mocks.NewMockIDistributedEnforcer(ctl)
+
+	dir, err := ioutil.TempDir("", "casbin-hraft-")
 	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	p, err := NewPolicyOperator(zap.NewExample(), dir, e)
+	assert.NoError(t, err)
+
+	e.EXPECT().AddPoliciesSelf(nil, "p", "p", [][]string{{"role:admin", "/", "*"}}).Return([][]string{{"role:admin", "/", "*"}}, nil)
+	e.EXPECT().RemoveFilteredPolicySelf(nil, "p", "p", 0, "role:user").Return(nil, errors.New("test error"))
+	e.EXPECT().ClearPolicySelf(nil)
+	err = p.Transaction(func(tx *PolicyTx) error {
+		_, err := tx.AddPolicies("p", "p", [][]string{{"role:admin", "/", "*"}})
+		if err != nil {
+			return err
+		}
+		_, err = tx.RemoveFilteredPolicy("p", "p", 0, "role:user")
+		return err
+	})
+	assert.Error(t, err)
+
+	err = p.db.View(func(tx *bolt.Tx) error {
+		assert.Equal(t, 0, tx.Bucket(policyBucketName).Stats().KeyN)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), p.Revision())
+
+	e.EXPECT().AddPoliciesSelf(nil, "p", "p", [][]string{{"role:admin", "/", "*"}}).Return([][]string{{"role:admin", "/", "*"}}, nil)
+	e.EXPECT().RemoveFilteredPolicySelf(nil, "p", "p", 0, "role:user").Return(nil, nil)
+	err = p.Transaction(func(tx *PolicyTx) error {
+		_, err := tx.AddPolicies("p", "p", [][]string{{"role:admin", "/", "*"}})
+		if err != nil {
+			return err
+		}
+		_, err = tx.RemoveFilteredPolicy("p", "p", 0, "role:user")
+		return err
+	})
+	assert.NoError(t, err)
+
+	err = p.db.View(func(tx *bolt.Tx) error {
+		assert.Equal(t, 1, tx.Bucket(policyBucketName).Stats().KeyN)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), p.Revision())
+}
+
+func TestPolicyOperator_ExpirePolicies(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	e := mocks.NewMockIDistributedEnforcer(ctl)
+
+	dir, err := ioutil.TempDir("", "casbin-hraft-")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	p, err := NewPolicyOperator(zap.NewExample(), dir, e)
+	assert.NoError(t, err)
+
+	rules := [][]string{{"role:admin", "/", "*"}, {"role:user", "/", "GET"}}
+	e.EXPECT().AddPoliciesSelf(nil, "p", "p", rules).Return(rules, nil)
+	_, err = p.AddExpiringPolicies("p", "p", rules, []int64{100, 0})
+	assert.NoError(t, err)
+
+	next, ok, err := p.NextExpiration()
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, int64(100), next)
+
+	effected, err := p.ExpirePolicies(99)
+	assert.NoError(t, err)
+	assert.Empty(t, effected)
+
+	e.EXPECT().RemovePoliciesSelf(nil, "p", "p", [][]string{{"role:admin", "/", "*"}}).Return([][]string{{"role:admin", "/", "*"}}, nil)
+	effected, err = p.ExpirePolicies(100)
+	assert.NoError(t, err)
+	assert.Equal(t, []Rule{{Sec: "p", PType: "p", Rule: []string{"role:admin", "/", "*"}}}, effected)
+
+	_, ok, err = p.NextExpiration()
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	_, err = p.AddExpiringPolicies("p", "p", rules, []int64{100})
+	assert.Error(t, err)
+}
+
+func TestPolicyOperator_SaveRequest(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	e := mocks.NewMockIDistributedEnforcer(ctl)
+
+	dir, err := ioutil.TempDir("", "casbin-hraft-")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	p, err := NewPolicyOperator(zap.NewExample(), dir, e)
+	assert.NoError(t, err)
+	p.requestLimit = 2
+
+	for _, id := range []string{"a", "b", "c"} {
+		err = p.SaveRequest(id, []byte(id))
+		assert.NoError(t, err)
+	}
+
+	value, err := p.LoadRequest("a")
+	assert.NoError(t, err)
+	assert.Nil(t, value)
+
+	for _, id := range []string{"b", "c"} {
+		value, err = p.LoadRequest(id)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte(id), value)
+	}
 }
 
 func TestPolicyOperator_LoadPolicy(t *testing.T) {
@@ -102,7 +236,7 @@ func TestPolicyOperator_LoadPolicy(t *testing.T) {
 	assert.NoError(t, err)
 
 	e.EXPECT().AddPoliciesSelf(nil, "p", "p", [][]string{{"role:admin", "/", "*"}, {"role:user", "/", "GET"}}).Return([][]string{{"role:admin", "/", "*"}, {"role:user", "/", "GET"}}, nil)
-	err = p.AddPolicies("p", "p", [][]string{{"role:admin", "/", "*"}, {"role:user", "/", "GET"}})
+	_, err = p.AddPolicies("p", "p", [][]string{{"role:admin", "/", "*"}, {"role:user", "/", "GET"}})
 	assert.NoError(t, err)
 
 	e.EXPECT().ClearPolicySelf(nil)
@@ -112,6 +246,55 @@ func TestPolicyOperator_LoadPolicy(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestPolicyOperator_ApplyLog(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	e := mocks.NewMockIDistributedEnforcer(ctl)
+
+	dir, err := ioutil.TempDir("", "casbin-hraft-")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	p, err := NewPolicyOperator(zap.NewExample(), dir, e)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), p.AppliedIndex())
+
+	e.EXPECT().AddPoliciesSelf(nil, "p", "p", [][]string{{"role:admin", "/", "*"}}).Return([][]string{{"role:admin", "/", "*"}}, nil)
+	err = p.applyLog(3, func(t *PolicyTx) error {
+		_, err := t.AddPolicies("p", "p", [][]string{{"role:admin", "/", "*"}})
+		return err
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), p.AppliedIndex())
+	assert.Equal(t, uint64(1), p.Revision())
+
+	// a failed log is rolled back, and the enforcer is reloaded since it is changed.
+	e.EXPECT().AddPoliciesSelf(nil, "p", "p", [][]string{{"role:user", "/", "GET"}}).Return([][]string{{"role:user", "/", "GET"}}, nil)
+	e.EXPECT().ClearPolicySelf(nil)
+	e.EXPECT().AddPoliciesSelf(nil, "p", "p", [][]string{{"role:admin", "/", "*"}})
+	err = p.applyLog(4, func(t *PolicyTx) error {
+		_, err := t.AddPolicies("p", "p", [][]string{{"role:user", "/", "GET"}})
+		if err != nil {
+			return err
+		}
+		return errors.New("invalid operation")
+	})
+	assert.EqualError(t, err, "invalid operation")
+	assert.Equal(t, uint64(3), p.AppliedIndex())
+	assert.Equal(t, uint64(1), p.Revision())
+	err = p.Close()
+	assert.NoError(t, err)
+
+	// the enforcer is loaded from the database when it is opened again.
+	e.EXPECT().ClearPolicySelf(nil)
+	e.EXPECT().AddPoliciesSelf(nil, "p", "p", [][]string{{"role:admin", "/", "*"}})
+	p, err = NewPolicyOperator(zap.NewExample(), dir, e)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), p.AppliedIndex())
+	assert.Equal(t, uint64(1), p.Revision())
+}
+
 func TestPolicyOperator_Backup_Restore(t *testing.T) {
 	ctl := gomock.NewController(t)
 	defer ctl.Finish()
@@ -126,7 +309,7 @@ func TestPolicyOperator_Backup_Restore(t *testing.T) {
 	assert.NoError(t, err)
 
 	e.EXPECT().AddPoliciesSelf(nil, "p", "p", [][]string{{"role:admin", "/", "*"}, {"role:user", "/", "GET"}}).Return([][]string{{"role:admin", "/", "*"}, {"role:user", "/", "GET"}}, nil)
-	err = p.AddPolicies("p", "p", [][]string{{"role:admin", "/", "*"}, {"role:user", "/", "GET"}})
+	_, err = p.AddPolicies("p", "p", [][]string{{"role:admin", "/", "*"}, {"role:user", "/", "GET"}})
 	assert.NoError(t, err)
 
 	b, err := p.Backup()
@@ -138,4 +321,5 @@ func TestPolicyOperator_Backup_Restore(t *testing.T) {
 	e.EXPECT().AddPoliciesSelf(nil, "p", "p", [][]string{{"role:user", "/", "GET"}})
 	err = p.Restore(ioutil.NopCloser(bytes.NewBuffer(b)))
 	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), p.Revision())
 }
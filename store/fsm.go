@@ -3,7 +3,10 @@ package store
 import (
 	"fmt"
 
+	"github.com/pkg/errors"
+
 	"github.com/casbin/hraft-dispatcher/command"
+	"github.com/casbin/hraft-dispatcher/http"
 	"google.golang.org/protobuf/proto"
 
 	"io"
@@ -17,6 +20,8 @@ import (
 type FSM struct {
 	logger         *zap.Logger
 	policyOperator *PolicyOperator
+	// sink receives the changes of the rules if it is not nil.
+	sink *sink
 }
 
 // NewFSM returns a FSM.
@@ -35,6 +40,8 @@ func NewFSM(logger *zap.Logger, path string, enforcer casbin.IDistributedEnforce
 
 // Apply applies log from raft.
 // It will parse the command of casbin from log, and pass the command to casbin.
+// If the command has a precondition that does not hold, the command is rejected with an http.RevisionConflictError.
+// If the command has a request ID that has been applied, the response of the applied command is returned.
 func (f *FSM) Apply(log *raft.Log) interface{} {
 	var cmd command.Command
 	err := proto.Unmarshal(log.Data, &cmd)
@@ -42,6 +49,97 @@ func (f *FSM) Apply(log *raft.Log) interface{} {
 		f.logger.Error("cannot to unmarshal the command", zap.Error(err), zap.ByteString("command", log.Data))
 		return err
 	}
+
+	// the database holds the logs up to the applied index, raft replays them if the node restarts without a snapshot.
+	if log.Index <= f.policyOperator.AppliedIndex() {
+		f.logger.Debug("skip the log applied to the database", zap.Uint64("index", log.Index))
+		return &command.ApplyResponse{Index: log.Index, Revision: f.policyOperator.Revision()}
+	}
+
+	if len(cmd.RequestId) != 0 {
+		resp, err := f.loadRequest(cmd.RequestId)
+		if err != nil {
+			f.logger.Error("failed to load the response of the request", zap.Error(err), zap.String("requestID", cmd.RequestId))
+			return err
+		}
+		if resp != nil {
+			f.logger.Info("skip the duplicate request", zap.String("requestID", cmd.RequestId))
+			return resp
+		}
+	}
+
+	if cmd.Precondition != nil {
+		revision := f.policyOperator.Revision()
+		if cmd.Precondition.Revision != revision {
+			f.logger.Info("the precondition of the command failed",
+				zap.Uint64("expectedRevision", cmd.Precondition.Revision),
+				zap.Uint64("revision", revision),
+			)
+			return http.NewRevisionConflictError(cmd.Precondition.Revision, revision)
+		}
+	}
+
+	// the response of the request is saved with the changes and the applied index,
+	// so a replayed log is neither applied again nor taken as a duplicate.
+	var ret interface{}
+	err = f.policyOperator.applyLog(log.Index, func(t *PolicyTx) error {
+		ret = f.applyCommand(t, log, &cmd)
+		if err, ok := ret.(error); ok {
+			return err
+		}
+		resp, ok := ret.(*command.ApplyResponse)
+		if !ok {
+			return nil
+		}
+		resp.Revision = t.Revision()
+
+		if len(cmd.RequestId) != 0 {
+			err := f.saveRequest(t, cmd.RequestId, resp)
+			if err != nil {
+				f.logger.Error("failed to save the response of the request", zap.Error(err), zap.String("requestID", cmd.RequestId))
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	changes := f.policyOperator.takeChanges()
+	if f.sink != nil && len(changes) != 0 {
+		f.sink.record(log.Index, changes)
+	}
+	return ret
+}
+
+// loadRequest returns the response of the applied command with the given request ID,
+// or nil if there is no such command.
+func (f *FSM) loadRequest(id string) (*command.ApplyResponse, error) {
+	data, err := f.policyOperator.LoadRequest(id)
+	if err != nil || data == nil {
+		return nil, err
+	}
+
+	var resp command.ApplyResponse
+	err = proto.Unmarshal(data, &resp)
+	if err != nil {
+		return nil, err
+	}
+	resp.Duplicate = true
+	return &resp, nil
+}
+
+// saveRequest saves the response of the applied command with the given request ID within the transaction of the log.
+func (f *FSM) saveRequest(t *PolicyTx, id string, resp *command.ApplyResponse) error {
+	data, err := proto.Marshal(resp)
+	if err != nil {
+		return err
+	}
+	return t.SaveRequest(id, data)
+}
+
+// applyCommand applies the command to the policies.
+func (f *FSM) applyCommand(t *PolicyTx, log *raft.Log, cmd *command.Command) interface{} {
 	switch cmd.Type {
 	case command.Command_COMMAND_TYPE_ADD_POLICIES:
 		var request command.AddPoliciesRequest
@@ -54,17 +152,18 @@ func (f *FSM) Apply(log *raft.Log) interface{} {
 		for _, rule := range request.Rules {
 			rules = append(rules, rule.GetItems())
 		}
-		err = f.policyOperator.AddPolicies(request.Sec, request.PType, rules)
+		effected, err := t.AddExpiringPolicies(request.Sec, request.PType, rules, request.ExpiresAt)
 		if err != nil {
 			f.logger.Error("apply the add policies request failed", zap.Error(err), zap.String("request", request.String()))
-		} else {
-			f.logger.Info("add policies request applied",
-				zap.String("sec", request.Sec),
-				zap.String("pType", request.PType),
-				zap.Any("rules", rules),
-			)
+			return err
 		}
-		return err
+		f.logger.Info("add policies request applied",
+			zap.String("sec", request.Sec),
+			zap.String("pType", request.PType),
+			zap.Any("rules", rules),
+			zap.Int64s("expiresAt", request.ExpiresAt),
+		)
+		return newApplyResponse(log.Index, len(effected) != 0, effected)
 	case command.Command_COMMAND_TYPE_REMOVE_POLICIES:
 		var request command.RemovePoliciesRequest
 		err := proto.Unmarshal(cmd.Data, &request)
@@ -76,17 +175,17 @@ func (f *FSM) Apply(log *raft.Log) interface{} {
 		for _, rule := range request.Rules {
 			rules = append(rules, rule.GetItems())
 		}
-		err = f.policyOperator.RemovePolicies(request.Sec, request.PType, rules)
+		effected, err := t.RemovePolicies(request.Sec, request.PType, rules)
 		if err != nil {
 			f.logger.Error("apply the remove policies request failed", zap.Error(err), zap.String("request", request.String()))
-		} else {
-			f.logger.Info("remove policies request applied",
-				zap.String("sec", request.Sec),
-				zap.String("pType", request.PType),
-				zap.Any("rules", rules),
-			)
+			return err
 		}
-		return err
+		f.logger.Info("remove policies request applied",
+			zap.String("sec", request.Sec),
+			zap.String("pType", request.PType),
+			zap.Any("rules", rules),
+		)
+		return newApplyResponse(log.Index, len(effected) != 0, effected)
 	case command.Command_COMMAND_TYPE_REMOVE_FILTERED_POLICY:
 		var request command.RemoveFilteredPolicyRequest
 		err := proto.Unmarshal(cmd.Data, &request)
@@ -94,18 +193,18 @@ func (f *FSM) Apply(log *raft.Log) interface{} {
 			f.logger.Error("cannot to unmarshal the request", zap.Error(err), zap.ByteString("request", cmd.Data))
 			return err
 		}
-		err = f.policyOperator.RemoveFilteredPolicy(request.Sec, request.PType, int(request.FieldIndex), request.FieldValues...)
+		effected, err := t.RemoveFilteredPolicy(request.Sec, request.PType, int(request.FieldIndex), request.FieldValues...)
 		if err != nil {
 			f.logger.Error("apply the remove filtered policy request failed", zap.Error(err), zap.String("request", request.String()))
-		} else {
-			f.logger.Info("remove filtered policy request applied",
-				zap.String("sec", request.Sec),
-				zap.String("pType", request.PType),
-				zap.Int32("fieldIndex", request.FieldIndex),
-				zap.Any("fieldValues", request.FieldValues),
-			)
+			return err
 		}
-		return err
+		f.logger.Info("remove filtered policy request applied",
+			zap.String("sec", request.Sec),
+			zap.String("pType", request.PType),
+			zap.Int32("fieldIndex", request.FieldIndex),
+			zap.Any("fieldValues", request.FieldValues),
+		)
+		return newApplyResponse(log.Index, len(effected) != 0, effected)
 	case command.Command_COMMAND_TYPE_UPDATE_POLICY:
 		var request command.UpdatePolicyRequest
 		err := proto.Unmarshal(cmd.Data, &request)
@@ -113,18 +212,21 @@ func (f *FSM) Apply(log *raft.Log) interface{} {
 			f.logger.Error("cannot to unmarshal the request", zap.Error(err), zap.ByteString("request", cmd.Data))
 			return err
 		}
-		err = f.policyOperator.UpdatePolicy(request.Sec, request.PType, request.OldRule, request.NewRule)
+		effected, err := t.UpdatePolicy(request.Sec, request.PType, request.OldRule, request.NewRule)
 		if err != nil {
 			f.logger.Error("apply the update policy request failed", zap.Error(err), zap.String("request", request.String()))
-		} else {
-			f.logger.Info("update policy request applied",
-				zap.String("sec", request.Sec),
-				zap.String("pType", request.PType),
-				zap.Any("oldRule", request.OldRule),
-				zap.Any("newRule", request.NewRule),
-			)
+			return err
 		}
-		return err
+		f.logger.Info("update policy request applied",
+			zap.String("sec", request.Sec),
+			zap.String("pType", request.PType),
+			zap.Any("oldRule", request.OldRule),
+			zap.Any("newRule", request.NewRule),
+		)
+		if !effected {
+			return newApplyResponse(log.Index, false, nil)
+		}
+		return newApplyResponse(log.Index, true, [][]string{request.OldRule})
 	case command.Command_COMMAND_TYPE_UPDATE_POLICIES:
 		var request command.UpdatePoliciesRequest
 		err := proto.Unmarshal(cmd.Data, &request)
@@ -141,18 +243,21 @@ func (f *FSM) Apply(log *raft.Log) interface{} {
 			newRules = append(newRules, rule.GetItems())
 		}
 
-		err = f.policyOperator.UpdatePolicies(request.Sec, request.PType, oldRules, newRules)
+		effected, err := t.UpdatePolicies(request.Sec, request.PType, oldRules, newRules)
 		if err != nil {
 			f.logger.Error("apply the update policies request failed", zap.Error(err), zap.String("request", request.String()))
-		} else {
-			f.logger.Info("update policies request applied",
-				zap.String("sec", request.Sec),
-				zap.String("pType", request.PType),
-				zap.Any("oldRules", request.OldRules),
-				zap.Any("newRules", request.NewRules),
-			)
+			return err
 		}
-		return err
+		f.logger.Info("update policies request applied",
+			zap.String("sec", request.Sec),
+			zap.String("pType", request.PType),
+			zap.Any("oldRules", request.OldRules),
+			zap.Any("newRules", request.NewRules),
+		)
+		if !effected {
+			return newApplyResponse(log.Index, false, nil)
+		}
+		return newApplyResponse(log.Index, true, oldRules)
 	case command.Command_COMMAND_TYPE_UPDATE_FILTERED_POLICIES:
 		var request command.UpdateFilteredPoliciesRequest
 		err := proto.Unmarshal(cmd.Data, &request)
@@ -169,26 +274,176 @@ func (f *FSM) Apply(log *raft.Log) interface{} {
 			newRules = append(newRules, rule.GetItems())
 		}
 
-		err = f.policyOperator.UpdateFilteredPolicies(request.Sec, request.PType, oldRules, newRules)
+		effected, err := t.UpdateFilteredPolicies(request.Sec, request.PType, oldRules, newRules)
 		if err != nil {
 			f.logger.Error("apply the update policies request failed", zap.Error(err), zap.String("request", request.String()))
-		} else {
-			f.logger.Info("update policies request applied",
-				zap.String("sec", request.Sec),
-				zap.String("pType", request.PType),
-				zap.Any("oldRules", request.OldRules),
-				zap.Any("newRules", request.NewRules),
-			)
+			return err
 		}
-		return err
+		f.logger.Info("update policies request applied",
+			zap.String("sec", request.Sec),
+			zap.String("pType", request.PType),
+			zap.Any("oldRules", request.OldRules),
+			zap.Any("newRules", request.NewRules),
+		)
+		if !effected {
+			return newApplyResponse(log.Index, false, nil)
+		}
+		return newApplyResponse(log.Index, true, oldRules)
 	case command.Command_COMMAND_TYPE_CLEAR_POLICY:
-		err := f.policyOperator.ClearPolicy()
+		err := t.ClearPolicy()
 		if err != nil {
 			f.logger.Error("apply the clear policy request failed", zap.Error(err))
-		} else {
-			f.logger.Info("clear policy request applied")
+			return err
 		}
-		return err
+		f.logger.Info("clear policy request applied")
+		return newApplyResponse(log.Index, true, nil)
+	case command.Command_COMMAND_TYPE_EXPIRE_POLICIES:
+		var request command.ExpirePoliciesRequest
+		err := proto.Unmarshal(cmd.Data, &request)
+		if err != nil {
+			f.logger.Error("cannot to unmarshal the request", zap.Error(err), zap.ByteString("request", cmd.Data))
+			return err
+		}
+		effected, err := t.ExpirePolicies(request.Now)
+		if err != nil {
+			f.logger.Error("apply the expire policies request failed", zap.Error(err), zap.String("request", request.String()))
+			return err
+		}
+		f.logger.Info("expire policies request applied",
+			zap.Int64("now", request.Now),
+			zap.Any("rules", effected),
+		)
+		var rules [][]string
+		for _, rule := range effected {
+			rules = append(rules, rule.Rule)
+		}
+		return newApplyResponse(log.Index, len(effected) != 0, rules)
+	case command.Command_COMMAND_TYPE_SCHEDULE_POLICIES:
+		var request command.SchedulePoliciesRequest
+		err := proto.Unmarshal(cmd.Data, &request)
+		if err != nil {
+			f.logger.Error("cannot to unmarshal the request", zap.Error(err), zap.ByteString("request", cmd.Data))
+			return err
+		}
+		rules := newRules(request.Rules)
+		effected, err := t.SchedulePolicies(request.Sec, request.PType, rules, request.ActivateAt, request.ExpiresAt)
+		if err != nil {
+			f.logger.Error("apply the schedule policies request failed", zap.Error(err), zap.String("request", request.String()))
+			return err
+		}
+		f.logger.Info("schedule policies request applied",
+			zap.String("sec", request.Sec),
+			zap.String("pType", request.PType),
+			zap.Any("rules", rules),
+			zap.Int64("activateAt", request.ActivateAt),
+			zap.Int64("expiresAt", request.ExpiresAt),
+		)
+		return newApplyResponse(log.Index, len(effected) != 0, effected)
+	case command.Command_COMMAND_TYPE_RESCHEDULE_POLICIES:
+		var request command.ReschedulePoliciesRequest
+		err := proto.Unmarshal(cmd.Data, &request)
+		if err != nil {
+			f.logger.Error("cannot to unmarshal the request", zap.Error(err), zap.ByteString("request", cmd.Data))
+			return err
+		}
+		rules := newRules(request.Rules)
+		effected, err := t.ReschedulePolicies(request.Sec, request.PType, rules, request.ActivateAt)
+		if err != nil {
+			f.logger.Error("apply the reschedule policies request failed", zap.Error(err), zap.String("request", request.String()))
+			return err
+		}
+		f.logger.Info("reschedule policies request applied",
+			zap.String("sec", request.Sec),
+			zap.String("pType", request.PType),
+			zap.Any("rules", rules),
+			zap.Int64("activateAt", request.ActivateAt),
+		)
+		return newApplyResponse(log.Index, len(effected) != 0, effected)
+	case command.Command_COMMAND_TYPE_CANCEL_SCHEDULED_POLICIES:
+		var request command.CancelScheduledPoliciesRequest
+		err := proto.Unmarshal(cmd.Data, &request)
+		if err != nil {
+			f.logger.Error("cannot to unmarshal the request", zap.Error(err), zap.ByteString("request", cmd.Data))
+			return err
+		}
+		rules := newRules(request.Rules)
+		effected, err := t.CancelScheduledPolicies(request.Sec, request.PType, rules)
+		if err != nil {
+			f.logger.Error("apply the cancel scheduled policies request failed", zap.Error(err), zap.String("request", request.String()))
+			return err
+		}
+		f.logger.Info("cancel scheduled policies request applied",
+			zap.String("sec", request.Sec),
+			zap.String("pType", request.PType),
+			zap.Any("rules", rules),
+		)
+		return newApplyResponse(log.Index, len(effected) != 0, effected)
+	case command.Command_COMMAND_TYPE_ACTIVATE_POLICIES:
+		var request command.ActivatePoliciesRequest
+		err := proto.Unmarshal(cmd.Data, &request)
+		if err != nil {
+			f.logger.Error("cannot to unmarshal the request", zap.Error(err), zap.ByteString("request", cmd.Data))
+			return err
+		}
+		effected, err := t.ActivatePolicies(request.Now)
+		if err != nil {
+			f.logger.Error("apply the activate policies request failed", zap.Error(err), zap.String("request", request.String()))
+			return err
+		}
+		f.logger.Info("activate policies request applied",
+			zap.Int64("now", request.Now),
+			zap.Any("rules", effected),
+		)
+		var rules [][]string
+		for _, rule := range effected {
+			rules = append(rules, rule.Rule)
+		}
+		return newApplyResponse(log.Index, len(effected) != 0, rules)
+	case command.Command_COMMAND_TYPE_BOOTSTRAP_POLICIES:
+		var request command.BootstrapPoliciesRequest
+		err := proto.Unmarshal(cmd.Data, &request)
+		if err != nil {
+			f.logger.Error("cannot to unmarshal the request", zap.Error(err), zap.ByteString("request", cmd.Data))
+			return err
+		}
+		resp, err := f.applyBootstrap(t, log.Index, &request)
+		if err != nil {
+			f.logger.Error("apply the bootstrap policies request failed", zap.Error(err))
+			return err
+		}
+		f.logger.Info("bootstrap policies request applied", zap.Bool("effected", resp.Effected))
+		return resp
+	case command.Command_COMMAND_TYPE_IMPORT_POLICIES:
+		var request command.ImportPoliciesRequest
+		err := proto.Unmarshal(cmd.Data, &request)
+		if err != nil {
+			f.logger.Error("cannot to unmarshal the request", zap.Error(err), zap.ByteString("request", cmd.Data))
+			return err
+		}
+		resp, err := f.applyImport(t, log.Index, &request)
+		if err != nil {
+			f.logger.Error("apply the import policies request failed", zap.Error(err))
+			return err
+		}
+		f.logger.Info("import policies request applied",
+			zap.Bool("replace", request.Replace),
+			zap.Int("policies", len(request.Policies)),
+		)
+		return resp
+	case command.Command_COMMAND_TYPE_TRANSACTION:
+		var request command.TransactionRequest
+		err := proto.Unmarshal(cmd.Data, &request)
+		if err != nil {
+			f.logger.Error("cannot to unmarshal the request", zap.Error(err), zap.ByteString("request", cmd.Data))
+			return err
+		}
+		resp, err := f.applyTransaction(t, log.Index, &request)
+		if err != nil {
+			f.logger.Error("apply the transaction request failed", zap.Error(err), zap.String("request", request.String()))
+			return err
+		}
+		f.logger.Info("transaction request applied", zap.Int("operations", len(request.Operations)))
+		return resp
 	default:
 		err := fmt.Errorf("unknown command: %v", log)
 		f.logger.Error(err.Error())
@@ -196,6 +451,53 @@ func (f *FSM) Apply(log *raft.Log) interface{} {
 	}
 }
 
+// Close closes the database of the policies.
+func (f *FSM) Close() error {
+	return f.policyOperator.Close()
+}
+
+// Revision returns the revision of the policies.
+func (f *FSM) Revision() uint64 {
+	return f.policyOperator.Revision()
+}
+
+// NextExpiration returns the earliest expiration time of the rules in unix seconds,
+// ok is false if no rule expires.
+func (f *FSM) NextExpiration() (int64, bool, error) {
+	return f.policyOperator.NextExpiration()
+}
+
+// NextActivation returns the earliest activation time of the pending rules in unix seconds,
+// ok is false if no rule is pending.
+func (f *FSM) NextActivation() (int64, bool, error) {
+	return f.policyOperator.NextActivation()
+}
+
+// PendingPolicies returns the rules waiting to be activated.
+func (f *FSM) PendingPolicies() ([]*command.PendingPolicy, error) {
+	pending, err := f.policyOperator.PendingPolicies()
+	if err != nil {
+		return nil, err
+	}
+
+	var policies []*command.PendingPolicy
+	for _, item := range pending {
+		policies = append(policies, &command.PendingPolicy{
+			Sec:        item.Sec,
+			PType:      item.PType,
+			Rule:       item.Rule.Rule,
+			ActivateAt: item.ActivateAt,
+			ExpiresAt:  item.ExpiresAt,
+		})
+	}
+	return policies, nil
+}
+
+// Adapter returns a read-only Adapter over the database of the FSM.
+func (f *FSM) Adapter() *Adapter {
+	return NewAdapter(f.policyOperator)
+}
+
 // Restore is used to restore an FSM from a snapshot. It is not called
 // concurrently with any other command. The FSM must discard all previous
 // state.
@@ -206,8 +508,12 @@ func (f *FSM) Restore(rc io.ReadCloser) error {
 	err := f.policyOperator.Restore(rc)
 	if err != nil {
 		f.logger.Error("failed to restore an FSM from the snapshot", zap.Error(err))
+		return err
+	}
+	if f.sink != nil {
+		f.sink.restored()
 	}
-	return err
+	return nil
 }
 
 // Snapshot is used to support log compaction. This call should
@@ -250,3 +556,178 @@ func (f *fsmSnapshot) Persist(sink raft.SnapshotSink) error {
 func (f *fsmSnapshot) Release() {
 	// noop
 }
+
+// newApplyResponse returns the result of an applied command.
+func newApplyResponse(index uint64, effected bool, effectedRules [][]string) *command.ApplyResponse {
+	resp := &command.ApplyResponse{
+		Index:    index,
+		Effected: effected,
+	}
+	for _, rule := range effectedRules {
+		resp.EffectedRules = append(resp.EffectedRules, &command.StringArray{Items: rule})
+	}
+	return resp
+}
+
+// applyTransaction applies the operations of a transaction atomically.
+// If any operation fails, none of the operations is applied, since the log is rolled back.
+func (f *FSM) applyTransaction(t *PolicyTx, index uint64, request *command.TransactionRequest) (*command.ApplyResponse, error) {
+	resp := newApplyResponse(index, false, nil)
+	for i, op := range request.Operations {
+		result, err := applyOperation(t, index, op)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to apply the operation %d", i)
+		}
+		resp.Effected = resp.Effected || result.Effected
+		resp.Results = append(resp.Results, result)
+	}
+	return resp, nil
+}
+
+// applyBootstrap adds the initial policies of the cluster atomically.
+// The policies are added only by the first bootstrap command, the later ones are not effected.
+func (f *FSM) applyBootstrap(t *PolicyTx, index uint64, request *command.BootstrapPoliciesRequest) (*command.ApplyResponse, error) {
+	resp := newApplyResponse(index, false, nil)
+	if t.Bootstrapped() {
+		return resp, nil
+	}
+	for _, item := range request.Policies {
+		_, err := t.AddPolicies(item.Sec, item.PType, newRules(item.Rules))
+		if err != nil {
+			return nil, err
+		}
+	}
+	resp.Effected = true
+	err := t.SetBootstrapped()
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// applyImport adds the imported policies atomically, the current policies are cleared first if request.Replace is set.
+// The consecutive policies of the same type are added in a batch.
+func (f *FSM) applyImport(t *PolicyTx, index uint64, request *command.ImportPoliciesRequest) (*command.ApplyResponse, error) {
+	resp := newApplyResponse(index, false, nil)
+	if request.Replace {
+		err := t.ClearPolicy()
+		if err != nil {
+			return nil, err
+		}
+		resp.Effected = true
+	}
+
+	policies := request.Policies
+	for len(policies) != 0 {
+		sec, pType := policies[0].Sec, policies[0].PType
+		var rules [][]string
+		for len(policies) != 0 && policies[0].Sec == sec && policies[0].PType == pType {
+			rules = append(rules, policies[0].Rule)
+			policies = policies[1:]
+		}
+
+		effected, err := t.AddPolicies(sec, pType, rules)
+		if err != nil {
+			return nil, err
+		}
+		if len(effected) != 0 {
+			resp.Effected = true
+		}
+	}
+	return resp, nil
+}
+
+// ForEachPolicy calls fn for each rule of the FSM.
+func (f *FSM) ForEachPolicy(fn func(policy *command.Policy) error) error {
+	return f.policyOperator.forEachRule(func(rule Rule) error {
+		return fn(&command.Policy{Sec: rule.Sec, PType: rule.PType, Rule: rule.Rule})
+	})
+}
+
+// Bootstrapped reports whether the cluster is seeded with the initial policies.
+func (f *FSM) Bootstrapped() (bool, error) {
+	return f.policyOperator.Bootstrapped()
+}
+
+// applyOperation applies an operation of a transaction.
+func applyOperation(t *PolicyTx, index uint64, op *command.TransactionOperation) (*command.ApplyResponse, error) {
+	n := 0
+	for _, set := range []bool{
+		op.AddPolicies != nil,
+		op.RemovePolicies != nil,
+		op.RemoveFilteredPolicy != nil,
+		op.UpdatePolicy != nil,
+		op.UpdatePolicies != nil,
+		op.UpdateFilteredPolicies != nil,
+		op.ClearPolicy,
+	} {
+		if set {
+			n++
+		}
+	}
+	if n != 1 {
+		return nil, fmt.Errorf("an operation must contain exactly one request, got %d", n)
+	}
+
+	switch {
+	case op.AddPolicies != nil:
+		request := op.AddPolicies
+		effected, err := t.AddExpiringPolicies(request.Sec, request.PType, newRules(request.Rules), request.ExpiresAt)
+		if err != nil {
+			return nil, err
+		}
+		return newApplyResponse(index, len(effected) != 0, effected), nil
+	case op.RemovePolicies != nil:
+		request := op.RemovePolicies
+		effected, err := t.RemovePolicies(request.Sec, request.PType, newRules(request.Rules))
+		if err != nil {
+			return nil, err
+		}
+		return newApplyResponse(index, len(effected) != 0, effected), nil
+	case op.RemoveFilteredPolicy != nil:
+		request := op.RemoveFilteredPolicy
+		effected, err := t.RemoveFilteredPolicy(request.Sec, request.PType, int(request.FieldIndex), request.FieldValues...)
+		if err != nil {
+			return nil, err
+		}
+		return newApplyResponse(index, len(effected) != 0, effected), nil
+	case op.UpdatePolicy != nil:
+		request := op.UpdatePolicy
+		effected, err := t.UpdatePolicy(request.Sec, request.PType, request.OldRule, request.NewRule)
+		if err != nil || !effected {
+			return newApplyResponse(index, false, nil), err
+		}
+		return newApplyResponse(index, true, [][]string{request.OldRule}), nil
+	case op.UpdatePolicies != nil:
+		request := op.UpdatePolicies
+		oldRules := newRules(request.OldRules)
+		effected, err := t.UpdatePolicies(request.Sec, request.PType, oldRules, newRules(request.NewRules))
+		if err != nil || !effected {
+			return newApplyResponse(index, false, nil), err
+		}
+		return newApplyResponse(index, true, oldRules), nil
+	case op.UpdateFilteredPolicies != nil:
+		request := op.UpdateFilteredPolicies
+		oldRules := newRules(request.OldRules)
+		effected, err := t.UpdateFilteredPolicies(request.Sec, request.PType, oldRules, newRules(request.NewRules))
+		if err != nil || !effected {
+			return newApplyResponse(index, false, nil), err
+		}
+		return newApplyResponse(index, true, oldRules), nil
+	default:
+		err := t.ClearPolicy()
+		if err != nil {
+			return nil, err
+		}
+		return newApplyResponse(index, true, nil), nil
+	}
+}
+
+// newRules converts a set of StringArray to rules.
+func newRules(items []*command.StringArray) [][]string {
+	var rules [][]string
+	for _, item := range items {
+		rules = append(rules, item.GetItems())
+	}
+	return rules
+}
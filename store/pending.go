@@ -0,0 +1,264 @@
+package store
+
+import (
+	"errors"
+	"strconv"
+
+	jsoniter "github.com/json-iterator/go"
+	bolt "go.etcd.io/bbolt"
+)
+
+// pendingBucketName holds the rules waiting to be activated.
+var pendingBucketName = []byte("pending_policies")
+
+// PendingRule is a rule waiting to be activated.
+type PendingRule struct {
+	Rule
+	// ActivateAt is the unix time the rule is added to the enforcer.
+	ActivateAt int64 `json:"activate_at"`
+	// ExpiresAt is the unix time the rule expires after it is activated, 0 means never.
+	ExpiresAt int64 `json:"expires_at,omitempty"`
+}
+
+type pendingValue struct {
+	ActivateAt int64 `json:"activate_at"`
+	ExpiresAt  int64 `json:"expires_at,omitempty"`
+}
+
+// SchedulePolicies adds a set of rules to be activated at the given unix time, and returns the rules scheduled.
+// A rule that is already pending is rescheduled.
+func (t *PolicyTx) SchedulePolicies(sec, pType string, rules [][]string, activateAt, expiresAt int64) ([][]string, error) {
+	if activateAt <= 0 {
+		return nil, errors.New("the activation time is not provided")
+	}
+	if expiresAt != 0 && expiresAt <= activateAt {
+		return nil, errors.New("the expiration time must be after the activation time")
+	}
+
+	bkt := t.tx.Bucket(pendingBucketName)
+	value, err := jsoniter.Marshal(pendingValue{ActivateAt: activateAt, ExpiresAt: expiresAt})
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range rules {
+		key, err := newRuleBytes(sec, pType, item)
+		if err != nil {
+			return nil, err
+		}
+		err = bkt.Put(key, value)
+		if err != nil {
+			return nil, err
+		}
+	}
+	t.record(changeSchedule, sec, pType, rules)
+	return rules, nil
+}
+
+// ReschedulePolicies changes the activation time of a set of pending rules, and returns the rules rescheduled.
+// The rules that are not pending are skipped.
+func (t *PolicyTx) ReschedulePolicies(sec, pType string, rules [][]string, activateAt int64) ([][]string, error) {
+	if activateAt <= 0 {
+		return nil, errors.New("the activation time is not provided")
+	}
+
+	var effected [][]string
+	bkt := t.tx.Bucket(pendingBucketName)
+	for _, item := range rules {
+		key, err := newRuleBytes(sec, pType, item)
+		if err != nil {
+			return nil, err
+		}
+		data := bkt.Get(key)
+		if data == nil {
+			continue
+		}
+
+		var value pendingValue
+		err = jsoniter.Unmarshal(data, &value)
+		if err != nil {
+			return nil, err
+		}
+		value.ActivateAt = activateAt
+		data, err = jsoniter.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		err = bkt.Put(key, data)
+		if err != nil {
+			return nil, err
+		}
+		effected = append(effected, item)
+	}
+	if len(effected) != 0 {
+		t.record(changeSchedule, sec, pType, effected)
+	}
+	return effected, nil
+}
+
+// CancelScheduledPolicies removes a set of pending rules, and returns the rules removed.
+func (t *PolicyTx) CancelScheduledPolicies(sec, pType string, rules [][]string) ([][]string, error) {
+	var effected [][]string
+	bkt := t.tx.Bucket(pendingBucketName)
+	for _, item := range rules {
+		key, err := newRuleBytes(sec, pType, item)
+		if err != nil {
+			return nil, err
+		}
+		if bkt.Get(key) == nil {
+			continue
+		}
+		err = bkt.Delete(key)
+		if err != nil {
+			return nil, err
+		}
+		effected = append(effected, item)
+	}
+	if len(effected) != 0 {
+		t.record(changeSchedule, sec, pType, effected)
+	}
+	return effected, nil
+}
+
+// ActivatePolicies adds the pending rules due at the given unix time to the enforcer, and returns the rules added.
+// The expiration time of a pending rule that is already in the enforcer replaces the one of the existing rule.
+func (t *PolicyTx) ActivatePolicies(now int64) ([]Rule, error) {
+	pending, err := listPendingRules(t.tx)
+	if err != nil {
+		return nil, err
+	}
+
+	var effected []Rule
+	for _, item := range pending {
+		if item.ActivateAt > now {
+			continue
+		}
+
+		rules, err := t.AddExpiringPolicies(item.Sec, item.PType, [][]string{item.Rule.Rule}, []int64{item.ExpiresAt})
+		if err != nil {
+			return nil, err
+		}
+		key, err := newRuleBytes(item.Sec, item.PType, item.Rule.Rule)
+		if err != nil {
+			return nil, err
+		}
+		if len(rules) != 0 {
+			effected = append(effected, item.Rule)
+		} else {
+			err = setExpiration(t.tx, key, item.ExpiresAt)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		err = t.tx.Bucket(pendingBucketName).Delete(key)
+		if err != nil {
+			return nil, err
+		}
+		t.record(changeSchedule, item.Sec, item.PType, [][]string{item.Rule.Rule})
+	}
+	return effected, nil
+}
+
+// setExpiration sets the expiration time of an existing rule, the zero time removes it.
+func setExpiration(tx *bolt.Tx, key []byte, expiresAt int64) error {
+	bkt := tx.Bucket(expirationBucketName)
+	if expiresAt == 0 {
+		return bkt.Delete(key)
+	}
+	return bkt.Put(key, []byte(strconv.FormatInt(expiresAt, 10)))
+}
+
+// SchedulePolicies adds a set of rules to be activated at the given unix time, and returns the rules scheduled.
+func (p *PolicyOperator) SchedulePolicies(sec, pType string, rules [][]string, activateAt, expiresAt int64) ([][]string, error) {
+	var effected [][]string
+	err := p.update(func(t *PolicyTx) error {
+		var err error
+		effected, err = t.SchedulePolicies(sec, pType, rules, activateAt, expiresAt)
+		return err
+	})
+	return effected, err
+}
+
+// ReschedulePolicies changes the activation time of a set of pending rules, and returns the rules rescheduled.
+func (p *PolicyOperator) ReschedulePolicies(sec, pType string, rules [][]string, activateAt int64) ([][]string, error) {
+	var effected [][]string
+	err := p.update(func(t *PolicyTx) error {
+		var err error
+		effected, err = t.ReschedulePolicies(sec, pType, rules, activateAt)
+		return err
+	})
+	return effected, err
+}
+
+// CancelScheduledPolicies removes a set of pending rules, and returns the rules removed.
+func (p *PolicyOperator) CancelScheduledPolicies(sec, pType string, rules [][]string) ([][]string, error) {
+	var effected [][]string
+	err := p.update(func(t *PolicyTx) error {
+		var err error
+		effected, err = t.CancelScheduledPolicies(sec, pType, rules)
+		return err
+	})
+	return effected, err
+}
+
+// ActivatePolicies adds the pending rules due at the given unix time to the enforcer, and returns the rules added.
+func (p *PolicyOperator) ActivatePolicies(now int64) ([]Rule, error) {
+	var effected []Rule
+	err := p.update(func(t *PolicyTx) error {
+		var err error
+		effected, err = t.ActivatePolicies(now)
+		return err
+	})
+	return effected, err
+}
+
+// PendingPolicies returns the rules waiting to be activated.
+func (p *PolicyOperator) PendingPolicies() ([]PendingRule, error) {
+	p.l.Lock()
+	defer p.l.Unlock()
+
+	var pending []PendingRule
+	err := p.db.View(func(tx *bolt.Tx) error {
+		var err error
+		pending, err = listPendingRules(tx)
+		return err
+	})
+	return pending, err
+}
+
+// NextActivation returns the earliest activation time of the pending rules in unix seconds,
+// ok is false if no rule is pending.
+func (p *PolicyOperator) NextActivation() (next int64, ok bool, err error) {
+	pending, err := p.PendingPolicies()
+	if err != nil {
+		return 0, false, err
+	}
+	for _, item := range pending {
+		if !ok || item.ActivateAt < next {
+			next, ok = item.ActivateAt, true
+		}
+	}
+	return next, ok, nil
+}
+
+// listPendingRules returns the pending rules in the database.
+func listPendingRules(tx *bolt.Tx) ([]PendingRule, error) {
+	var pending []PendingRule
+	err := tx.Bucket(pendingBucketName).ForEach(func(k, v []byte) error {
+		var item PendingRule
+		err := jsoniter.Unmarshal(k, &item.Rule)
+		if err != nil {
+			return err
+		}
+		var value pendingValue
+		err = jsoniter.Unmarshal(v, &value)
+		if err != nil {
+			return err
+		}
+		item.ActivateAt = value.ActivateAt
+		item.ExpiresAt = value.ExpiresAt
+		pending = append(pending, item)
+		return nil
+	})
+	return pending, err
+}
@@ -0,0 +1,161 @@
+package store
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/casbin/hraft-dispatcher/store/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicyOperator_ActivatePolicies(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	e := mocks.NewMockIDistributedEnforcer(ctl)
+
+	dir, err := ioutil.TempDir("", "casbin-hraft-")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	p, err := NewPolicyOperator(zap.NewExample(), dir, e)
+	assert.NoError(t, err)
+
+	_, err = p.SchedulePolicies("p", "p", [][]string{{"role:admin", "/", "*"}}, 0, 0)
+	assert.Error(t, err)
+	_, err = p.SchedulePolicies("p", "p", [][]string{{"role:admin", "/", "*"}}, 100, 100)
+	assert.EqualError(t, err, "the expiration time must be after the activation time")
+	_, err = p.SchedulePolicies("p", "p", [][]string{{"role:admin", "/", "*"}}, 100, 50)
+	assert.EqualError(t, err, "the expiration time must be after the activation time")
+
+	effected, err := p.SchedulePolicies("p", "p", [][]string{{"role:admin", "/", "*"}}, 100, 200)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"role:admin", "/", "*"}}, effected)
+	_, err = p.SchedulePolicies("p", "p", [][]string{{"role:user", "/", "GET"}}, 150, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), p.Revision())
+
+	pending, err := p.PendingPolicies()
+	assert.NoError(t, err)
+	assert.Equal(t, []PendingRule{
+		{Rule: Rule{Sec: "p", PType: "p", Rule: []string{"role:admin", "/", "*"}}, ActivateAt: 100, ExpiresAt: 200},
+		{Rule: Rule{Sec: "p", PType: "p", Rule: []string{"role:user", "/", "GET"}}, ActivateAt: 150},
+	}, pending)
+
+	effected, err = p.ReschedulePolicies("p", "p", [][]string{{"role:user", "/", "GET"}, {"role:guest", "/", "GET"}}, 50)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"role:user", "/", "GET"}}, effected)
+	assert.Equal(t, uint64(3), p.Revision())
+
+	_, err = p.ReschedulePolicies("p", "p", [][]string{{"role:guest", "/", "GET"}}, 50)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), p.Revision())
+
+	next, ok, err := p.NextActivation()
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, int64(50), next)
+
+	activated, err := p.ActivatePolicies(49)
+	assert.NoError(t, err)
+	assert.Empty(t, activated)
+	assert.Equal(t, uint64(3), p.Revision())
+
+	e.EXPECT().AddPoliciesSelf(nil, "p", "p", [][]string{{"role:user", "/", "GET"}}).Return([][]string{{"role:user", "/", "GET"}}, nil)
+	activated, err = p.ActivatePolicies(50)
+	assert.NoError(t, err)
+	assert.Equal(t, []Rule{{Sec: "p", PType: "p", Rule: []string{"role:user", "/", "GET"}}}, activated)
+	assert.Equal(t, uint64(4), p.Revision())
+
+	_, ok, err = p.NextExpiration()
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	e.EXPECT().AddPoliciesSelf(nil, "p", "p", [][]string{{"role:admin", "/", "*"}}).Return([][]string{{"role:admin", "/", "*"}}, nil)
+	activated, err = p.ActivatePolicies(100)
+	assert.NoError(t, err)
+	assert.Equal(t, []Rule{{Sec: "p", PType: "p", Rule: []string{"role:admin", "/", "*"}}}, activated)
+
+	next, ok, err = p.NextExpiration()
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, int64(200), next)
+
+	_, ok, err = p.NextActivation()
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	_, err = p.SchedulePolicies("p", "p", [][]string{{"role:guest", "/", "GET"}}, 300, 0)
+	assert.NoError(t, err)
+	effected, err = p.CancelScheduledPolicies("p", "p", [][]string{{"role:guest", "/", "GET"}, {"role:admin", "/", "*"}})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"role:guest", "/", "GET"}}, effected)
+	assert.Equal(t, uint64(7), p.Revision())
+
+	pending, err = p.PendingPolicies()
+	assert.NoError(t, err)
+	assert.Empty(t, pending)
+}
+
+func TestPolicyOperator_ActivateExistingPolicies(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	e := mocks.NewMockIDistributedEnforcer(ctl)
+
+	dir, err := ioutil.TempDir("", "casbin-hraft-")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	p, err := NewPolicyOperator(zap.NewExample(), dir, e)
+	assert.NoError(t, err)
+
+	e.EXPECT().AddPoliciesSelf(nil, "p", "p", [][]string{{"role:admin", "/", "*"}}).Return([][]string{{"role:admin", "/", "*"}}, nil)
+	_, err = p.AddPolicies("p", "p", [][]string{{"role:admin", "/", "*"}})
+	assert.NoError(t, err)
+
+	// the expiration time is attached to the existing rule.
+	_, err = p.SchedulePolicies("p", "p", [][]string{{"role:admin", "/", "*"}}, 100, 200)
+	assert.NoError(t, err)
+	e.EXPECT().AddPoliciesSelf(nil, "p", "p", [][]string{{"role:admin", "/", "*"}}).Return(nil, nil)
+	activated, err := p.ActivatePolicies(100)
+	assert.NoError(t, err)
+	assert.Empty(t, activated)
+	assert.Equal(t, uint64(3), p.Revision())
+
+	next, ok, err := p.NextExpiration()
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, int64(200), next)
+
+	// the expiration time of the existing rule is overwritten.
+	_, err = p.SchedulePolicies("p", "p", [][]string{{"role:admin", "/", "*"}}, 150, 300)
+	assert.NoError(t, err)
+	e.EXPECT().AddPoliciesSelf(nil, "p", "p", [][]string{{"role:admin", "/", "*"}}).Return(nil, nil)
+	_, err = p.ActivatePolicies(150)
+	assert.NoError(t, err)
+
+	next, ok, err = p.NextExpiration()
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, int64(300), next)
+
+	// a rule activated without an expiration time never expires.
+	_, err = p.SchedulePolicies("p", "p", [][]string{{"role:admin", "/", "*"}}, 160, 0)
+	assert.NoError(t, err)
+	e.EXPECT().AddPoliciesSelf(nil, "p", "p", [][]string{{"role:admin", "/", "*"}}).Return(nil, nil)
+	_, err = p.ActivatePolicies(160)
+	assert.NoError(t, err)
+
+	_, ok, err = p.NextExpiration()
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	pending, err := p.PendingPolicies()
+	assert.NoError(t, err)
+	assert.Empty(t, pending)
+}
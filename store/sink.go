@@ -0,0 +1,355 @@
+package store
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/casbin/casbin/v2/model"
+	"github.com/casbin/casbin/v2/persist"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	// sinkCheckpointName is the name of the file holding the index of the last raft log exported to the sink.
+	sinkCheckpointName = "sink_checkpoint"
+	// sinkRetryInterval is the interval the sink retries the failed exports and checks the leadership.
+	sinkRetryInterval = time.Second
+)
+
+type changeType int
+
+const (
+	changeAdd changeType = iota
+	changeRemove
+	changeClear
+	// changeSchedule is a change of the pending rules or of the expiration time of the rules,
+	// it is not exported to the sink since the rules of the enforcer are unchanged.
+	changeSchedule
+)
+
+// change is a change of the rules made by an applied command.
+type change struct {
+	Type  changeType
+	Sec   string
+	PType string
+	Rules [][]string
+}
+
+// setIndex sets the index of the raft log being applied, it is saved with the changes of the rules.
+func (p *PolicyOperator) setIndex(index uint64) {
+	p.l.Lock()
+	defer p.l.Unlock()
+
+	p.index = index
+}
+
+// takeChanges returns the changes of the rules made since the last call.
+func (p *PolicyOperator) takeChanges() []change {
+	p.l.Lock()
+	defer p.l.Unlock()
+
+	changes := p.changes
+	p.changes = nil
+	return changes
+}
+
+// ChangeIndex returns the index of the last raft log that changed the policies, 0 if it is unknown.
+func (p *PolicyOperator) ChangeIndex() (uint64, error) {
+	_, index, err := p.snapshot()
+	return index, err
+}
+
+// snapshot returns the rules in the database and the index of the last raft log that changed them.
+func (p *PolicyOperator) snapshot() ([]Rule, uint64, error) {
+	p.l.Lock()
+	tx, err := p.db.Begin(false)
+	p.l.Unlock()
+	if err != nil {
+		return nil, 0, err
+	}
+	defer tx.Rollback()
+
+	var index uint64
+	if value := tx.Bucket(metaBucketName).Get(changeIndexKey); value != nil {
+		index, err = strconv.ParseUint(string(value), 10, 64)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	var rules []Rule
+	err = tx.Bucket(policyBucketName).ForEach(func(k, v []byte) error {
+		var rule Rule
+		err := jsoniter.Unmarshal(k, &rule)
+		if err != nil {
+			return err
+		}
+		rules = append(rules, rule)
+		return nil
+	})
+	return rules, index, err
+}
+
+// SinkConfig configures the sink that mirrors the changes of the policies to an adapter.
+type SinkConfig struct {
+	// Adapter receives the changes of the policies, persist.BatchAdapter is used if it is implemented.
+	Adapter persist.Adapter
+	// Model defines the policy types written by Adapter.SavePolicy when the sink is resynced.
+	Model model.Model
+	// EveryNode exports the changes on every node, otherwise only the leader exports the changes.
+	EveryNode bool
+}
+
+type sinkEntry struct {
+	index   uint64
+	changes []change
+}
+
+// sink exports the changes of the rules applied to the current node to an adapter in the order of the raft log.
+// The index of the last exported log is saved to a checkpoint file, the logs replayed after a restart are skipped.
+// The sink is resynced with all rules by Adapter.SavePolicy if some changes cannot be exported incrementally,
+// such as after a snapshot is restored.
+type sink struct {
+	adapter        persist.Adapter
+	model          model.Model
+	everyNode      bool
+	isLeader       func() bool
+	p              *PolicyOperator
+	checkpointPath string
+	logger         *zap.Logger
+
+	mu         sync.Mutex
+	queue      []sinkEntry
+	checkpoint uint64
+	resync     bool
+	notifyCh   chan struct{}
+}
+
+// newSink returns a sink, the checkpoint is loaded from the given directory.
+func newSink(logger *zap.Logger, config *SinkConfig, dir string, p *PolicyOperator, isLeader func() bool) (*sink, error) {
+	if config.Adapter == nil {
+		return nil, errors.New("the adapter of the sink is not provided")
+	}
+	if config.Model == nil {
+		return nil, errors.New("the model of the sink is not provided")
+	}
+
+	m := config.Model.Copy()
+	m.ClearPolicy()
+	s := &sink{
+		adapter:        config.Adapter,
+		model:          m,
+		everyNode:      config.EveryNode,
+		isLeader:       isLeader,
+		p:              p,
+		checkpointPath: filepath.Join(dir, sinkCheckpointName),
+		logger:         logger,
+		notifyCh:       make(chan struct{}, 1),
+	}
+
+	data, err := ioutil.ReadFile(s.checkpointPath)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if len(data) != 0 {
+		s.checkpoint, err = strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid sink checkpoint")
+		}
+	}
+	return s, nil
+}
+
+// record queues the changes made by the raft log at the given index.
+func (s *sink) record(index uint64, changes []change) {
+	s.mu.Lock()
+	if index > s.checkpoint {
+		s.queue = append(s.queue, sinkEntry{index: index, changes: changes})
+	}
+	s.mu.Unlock()
+	s.notify()
+}
+
+// restored discards the queued changes replaced by a restored snapshot,
+// and resyncs the sink if the snapshot holds changes not exported yet.
+func (s *sink) restored() {
+	index, err := s.p.ChangeIndex()
+
+	s.mu.Lock()
+	s.queue = nil
+	if err != nil || index > s.checkpoint {
+		s.resync = true
+	}
+	s.mu.Unlock()
+	s.notify()
+}
+
+// Resync replaces all rules of the adapter with the rules applied to the current node.
+func (s *sink) Resync() {
+	s.mu.Lock()
+	s.resync = true
+	s.mu.Unlock()
+	s.notify()
+}
+
+func (s *sink) notify() {
+	select {
+	case s.notifyCh <- struct{}{}:
+	default:
+	}
+}
+
+// run exports the queued changes until stopCh is closed.
+func (s *sink) run(stopCh <-chan struct{}) {
+	ticker := time.NewTicker(sinkRetryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-s.notifyCh:
+		case <-ticker.C:
+		}
+		s.flush()
+	}
+}
+
+// flush exports the queued changes, the failed export is retried by the next call.
+func (s *sink) flush() {
+	if !s.everyNode && !s.isLeader() {
+		// the changes are exported by the leader, resync once the current node becomes the leader.
+		s.mu.Lock()
+		if len(s.queue) != 0 {
+			s.queue = nil
+			s.resync = true
+		}
+		s.mu.Unlock()
+		return
+	}
+
+	s.mu.Lock()
+	resync := s.resync
+	s.resync = false
+	s.mu.Unlock()
+	if resync {
+		err := s.resyncAll()
+		if err != nil {
+			s.logger.Error("failed to resync the sink", zap.Error(err))
+			s.Resync()
+			return
+		}
+	}
+
+	for {
+		s.mu.Lock()
+		if len(s.queue) == 0 {
+			s.mu.Unlock()
+			return
+		}
+		entry := s.queue[0]
+		checkpoint := s.checkpoint
+		s.mu.Unlock()
+
+		if entry.index > checkpoint {
+			err := s.export(entry.changes)
+			if err == nil {
+				err = s.saveCheckpoint(entry.index)
+			}
+			if err != nil {
+				s.logger.Error("failed to export the changes to the sink", zap.Uint64("index", entry.index), zap.Error(err))
+				return
+			}
+		}
+
+		s.mu.Lock()
+		// the queue may have been discarded by restored.
+		if len(s.queue) != 0 && s.queue[0].index == entry.index {
+			s.queue = s.queue[1:]
+		}
+		s.mu.Unlock()
+	}
+}
+
+// resyncAll saves all rules applied to the current node to the adapter.
+func (s *sink) resyncAll() error {
+	rules, index, err := s.p.snapshot()
+	if err != nil {
+		return err
+	}
+
+	m := s.model.Copy()
+	for _, rule := range rules {
+		err = addRuleToModel(m, rule)
+		if err != nil {
+			return err
+		}
+	}
+	err = s.adapter.SavePolicy(m)
+	if err != nil {
+		return err
+	}
+	s.logger.Info("the sink is resynced", zap.Uint64("index", index), zap.Int("rules", len(rules)))
+	return s.saveCheckpoint(index)
+}
+
+// export writes the changes to the adapter.
+func (s *sink) export(changes []change) error {
+	batchAdapter, isBatch := s.adapter.(persist.BatchAdapter)
+	for _, c := range changes {
+		var err error
+		switch c.Type {
+		case changeAdd:
+			if isBatch {
+				err = batchAdapter.AddPolicies(c.Sec, c.PType, c.Rules)
+				break
+			}
+			for _, rule := range c.Rules {
+				if err = s.adapter.AddPolicy(c.Sec, c.PType, rule); err != nil {
+					break
+				}
+			}
+		case changeRemove:
+			if isBatch {
+				err = batchAdapter.RemovePolicies(c.Sec, c.PType, c.Rules)
+				break
+			}
+			for _, rule := range c.Rules {
+				if err = s.adapter.RemovePolicy(c.Sec, c.PType, rule); err != nil {
+					break
+				}
+			}
+		case changeClear:
+			err = s.adapter.SavePolicy(s.model.Copy())
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// saveCheckpoint saves the index of the last exported raft log.
+func (s *sink) saveCheckpoint(index uint64) error {
+	tmpPath := s.checkpointPath + ".tmp"
+	err := ioutil.WriteFile(tmpPath, []byte(strconv.FormatUint(index, 10)), 0600)
+	if err != nil {
+		return err
+	}
+	err = os.Rename(tmpPath, s.checkpointPath)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.checkpoint = index
+	s.mu.Unlock()
+	return nil
+}
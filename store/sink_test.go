@@ -0,0 +1,191 @@
+package store
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/casbin/casbin/v2/model"
+	"github.com/hashicorp/raft"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/casbin/hraft-dispatcher/command"
+	"github.com/casbin/hraft-dispatcher/store/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryAdapter keeps the rules in memory, it is used to test the sink.
+type memoryAdapter struct {
+	mu    sync.Mutex
+	rules map[string]struct{}
+	saved int
+}
+
+func newMemoryAdapter() *memoryAdapter {
+	return &memoryAdapter{rules: make(map[string]struct{})}
+}
+
+func (a *memoryAdapter) Rules() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var rules []string
+	for rule := range a.rules {
+		rules = append(rules, rule)
+	}
+	sort.Strings(rules)
+	return rules
+}
+
+func (a *memoryAdapter) LoadPolicy(model.Model) error {
+	return nil
+}
+
+func (a *memoryAdapter) SavePolicy(m model.Model) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.saved++
+	a.rules = make(map[string]struct{})
+	for _, sec := range []string{"p", "g"} {
+		for pType, ast := range m[sec] {
+			for _, rule := range ast.Policy {
+				a.rules[pType+", "+strings.Join(rule, ", ")] = struct{}{}
+			}
+		}
+	}
+	return nil
+}
+
+func (a *memoryAdapter) AddPolicy(sec string, pType string, rule []string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.rules[pType+", "+strings.Join(rule, ", ")] = struct{}{}
+	return nil
+}
+
+func (a *memoryAdapter) RemovePolicy(sec string, pType string, rule []string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	delete(a.rules, pType+", "+strings.Join(rule, ", "))
+	return nil
+}
+
+func (a *memoryAdapter) RemoveFilteredPolicy(string, string, int, ...string) error {
+	return nil
+}
+
+func TestSink(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	e := mocks.NewMockIDistributedEnforcer(ctl)
+
+	dir, err := ioutil.TempDir("", "casbin-hraft-")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	p, err := NewPolicyOperator(zap.NewExample(), dir, e)
+	assert.NoError(t, err)
+	m, err := model.NewModelFromString(testModelText)
+	assert.NoError(t, err)
+
+	a := newMemoryAdapter()
+	config := &SinkConfig{Adapter: a, Model: m}
+	s, err := newSink(zap.NewExample(), config, dir, p, func() bool { return true })
+	assert.NoError(t, err)
+
+	p.setIndex(3)
+	e.EXPECT().AddPoliciesSelf(nil, "p", "p", [][]string{{"role:admin", "/", "*"}}).Return([][]string{{"role:admin", "/", "*"}}, nil)
+	_, err = p.AddPolicies("p", "p", [][]string{{"role:admin", "/", "*"}})
+	assert.NoError(t, err)
+	s.record(3, p.takeChanges())
+	s.flush()
+	assert.Equal(t, []string{"p, role:admin, /, *"}, a.Rules())
+
+	p.setIndex(4)
+	e.EXPECT().UpdatePolicySelf(nil, "p", "p", []string{"role:admin", "/", "*"}, []string{"role:admin", "/", "GET"}).Return(true, nil)
+	_, err = p.UpdatePolicy("p", "p", []string{"role:admin", "/", "*"}, []string{"role:admin", "/", "GET"})
+	assert.NoError(t, err)
+	changes := p.takeChanges()
+	s.record(4, changes)
+	s.flush()
+	assert.Equal(t, []string{"p, role:admin, /, GET"}, a.Rules())
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, sinkCheckpointName))
+	assert.NoError(t, err)
+	assert.Equal(t, "4", string(data))
+
+	// the logs replayed after a restart are skipped.
+	s, err = newSink(zap.NewExample(), config, dir, p, func() bool { return true })
+	assert.NoError(t, err)
+	s.record(4, changes)
+	assert.Empty(t, s.queue)
+
+	s.Resync()
+	s.flush()
+	assert.Equal(t, 1, a.saved)
+	assert.Equal(t, []string{"p, role:admin, /, GET"}, a.Rules())
+
+	// the changes missed by a follower are resynced once it becomes the leader.
+	isLeader := false
+	s, err = newSink(zap.NewExample(), config, dir, p, func() bool { return isLeader })
+	assert.NoError(t, err)
+	p.setIndex(5)
+	e.EXPECT().AddPoliciesSelf(nil, "g", "g", [][]string{{"alice", "role:admin"}}).Return([][]string{{"alice", "role:admin"}}, nil)
+	_, err = p.AddPolicies("g", "g", [][]string{{"alice", "role:admin"}})
+	assert.NoError(t, err)
+	s.record(5, p.takeChanges())
+	s.flush()
+	assert.Equal(t, []string{"p, role:admin, /, GET"}, a.Rules())
+
+	isLeader = true
+	s.flush()
+	assert.Equal(t, 2, a.saved)
+	assert.Equal(t, []string{"g, alice, role:admin", "p, role:admin, /, GET"}, a.Rules())
+}
+
+func TestFSM_Sink(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	e := mocks.NewMockIDistributedEnforcer(ctl)
+
+	dir, err := ioutil.TempDir("", "casbin-hraft-")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	f, err := NewFSM(zap.NewExample(), dir, e)
+	assert.NoError(t, err)
+	m, err := model.NewModelFromString(testModelText)
+	assert.NoError(t, err)
+	a := newMemoryAdapter()
+	f.sink, err = newSink(zap.NewExample(), &SinkConfig{Adapter: a, Model: m}, dir, f.policyOperator, func() bool { return true })
+	assert.NoError(t, err)
+
+	data, err := proto.Marshal(&command.AddPoliciesRequest{
+		Sec:   "p",
+		PType: "p",
+		Rules: []*command.StringArray{{Items: []string{"role:admin", "/", "*"}}},
+	})
+	assert.NoError(t, err)
+	data, err = proto.Marshal(&command.Command{Type: command.Command_COMMAND_TYPE_ADD_POLICIES, Data: data})
+	assert.NoError(t, err)
+
+	e.EXPECT().AddPoliciesSelf(nil, "p", "p", [][]string{{"role:admin", "/", "*"}}).Return([][]string{{"role:admin", "/", "*"}}, nil)
+	f.Apply(&raft.Log{Index: 7, Data: data})
+	f.sink.flush()
+	assert.Equal(t, []string{"p, role:admin, /, *"}, a.Rules())
+
+	index, err := f.policyOperator.ChangeIndex()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(7), index)
+}
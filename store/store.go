@@ -3,9 +3,11 @@ package store
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"path"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/casbin/hraft-dispatcher/store/logstore"
@@ -28,6 +30,9 @@ const (
 	raftDBName          = "raft.db"
 	retainSnapshotCount = 2
 	raftTimeout         = 10 * time.Second
+	applyCheckInterval  = 10 * time.Millisecond
+	// schedulerCheckInterval is the interval the leader checks the expired and pending rules.
+	schedulerCheckInterval = time.Second
 )
 
 var _ http.Store = &Store{}
@@ -45,10 +50,13 @@ type Store struct {
 	snapshotStore          raft.SnapshotStore
 	logStore               raft.LogStore
 	stableStore            raft.StableStore
-	fms                    raft.FSM
+	fsm                    *FSM
 	boltStore              *logstore.BoltStore
 
-	enforcer casbin.IDistributedEnforcer
+	enforcer   casbin.IDistributedEnforcer
+	sinkConfig *SinkConfig
+
+	shutdownCh chan struct{}
 
 	// inMemory is used for testing.
 	inMemory bool
@@ -62,6 +70,8 @@ type Config struct {
 	NetworkTransportConfig *raft.NetworkTransportConfig
 	Enforcer               casbin.IDistributedEnforcer
 	RaftConfig             *raft.Config
+	// Sink mirrors the changes of the policies to an adapter if it is not nil.
+	Sink *SinkConfig
 }
 
 // NewStore return a instance of Store.
@@ -73,6 +83,7 @@ func NewStore(logger *zap.Logger, config *Config) (*Store, error) {
 		networkTransportConfig: config.NetworkTransportConfig,
 		enforcer:               config.Enforcer,
 		raftConfig:             config.RaftConfig,
+		sinkConfig:             config.Sink,
 	}
 
 	return s, nil
@@ -136,6 +147,18 @@ func (s *Store) Start(enableBootstrap bool) error {
 		return err
 	}
 
+	s.fsm = fsm
+
+	if s.sinkConfig != nil {
+		fsm.sink, err = newSink(s.logger, s.sinkConfig, s.dataDir, fsm.policyOperator, func() bool {
+			return s.raft.State() == raft.Leader
+		})
+		if err != nil {
+			s.logger.Error("failed to new sink", zap.Error(err))
+			return err
+		}
+	}
+
 	ra, err := raft.NewRaft(config, fsm, s.logStore, s.stableStore, s.snapshotStore, s.transport)
 	if err != nil {
 		s.logger.Error("failed to new raft", zap.Error(err))
@@ -143,6 +166,12 @@ func (s *Store) Start(enableBootstrap bool) error {
 	}
 	s.raft = ra
 
+	s.shutdownCh = make(chan struct{})
+	go s.runScheduler()
+	if fsm.sink != nil {
+		go fsm.sink.run(s.shutdownCh)
+	}
+
 	if enableBootstrap {
 		configuration := raft.Configuration{
 			Servers: []raft.Server{
@@ -166,12 +195,22 @@ func (s *Store) Start(enableBootstrap bool) error {
 // Stop is used to close the raft node, which always returns nil.
 func (s *Store) Stop() error {
 	var result error
+	close(s.shutdownCh)
+
 	shutdown := s.raft.Shutdown()
 	if shutdown.Error() != nil {
 		s.logger.Error("failed to stop the raft server", zap.Error(shutdown.Error()))
 		result = multierror.Append(result, shutdown.Error())
 	}
 
+	if s.fsm != nil {
+		err := s.fsm.Close()
+		if err != nil {
+			s.logger.Error("failed to close the database of the policies", zap.Error(err))
+			result = multierror.Append(result, err)
+		}
+	}
+
 	if !s.inMemory {
 		err := s.boltStore.Close()
 		if err != nil {
@@ -237,120 +276,592 @@ func (s *Store) DataDir() string {
 	return s.dataDir
 }
 
-// applyProtoMessage applies a proto message.
-func (s *Store) applyProtoMessage(m proto.Message) error {
-	cmd, err := proto.Marshal(m)
+// applyCommand applies a command, and returns the response of the FSM.
+// If the FSM fails to apply the command, an http.ApplyError is returned.
+// If the revision set by http.WithExpectedRevision does not match, an http.RevisionConflictError is returned.
+// If the request ID set by http.WithRequestID has been applied, the response of the applied command is returned.
+// The deadline of ctx bounds the time to enqueue the command, raftTimeout is used if ctx has no deadline.
+// If ctx is done before the command is applied, ctx.Err() is returned, but the command may still be applied later.
+func (s *Store) applyCommand(ctx context.Context, c *command.Command) (*command.ApplyResponse, error) {
+	if revision, ok := http.ExpectedRevision(ctx); ok {
+		c.Precondition = &command.Precondition{Revision: revision}
+	}
+	if id, ok := http.RequestID(ctx); ok {
+		c.RequestId = id
+	}
+	cmd, err := proto.Marshal(c)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	timeout, err := raftTimeoutOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	f := s.raft.Apply(cmd, timeout)
+	err = waitFuture(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp := f.Response().(type) {
+	case *http.RevisionConflictError:
+		return nil, resp
+	case error:
+		return nil, http.NewApplyError(resp)
+	case *command.ApplyResponse:
+		return resp, nil
+	default:
+		return &command.ApplyResponse{Index: f.Index()}, nil
 	}
-	return s.raft.Apply(cmd, raftTimeout).Error()
 }
 
 // AddPolicy implements the http.Store interface.
-func (s *Store) AddPolicies(request *command.AddPoliciesRequest) error {
+func (s *Store) AddPolicies(ctx context.Context, request *command.AddPoliciesRequest) (*command.ApplyResponse, error) {
 	data, err := proto.Marshal(request)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	cmd := &command.Command{
 		Type: command.Command_COMMAND_TYPE_ADD_POLICIES,
 		Data: data,
 	}
-	return s.applyProtoMessage(cmd)
+	return s.applyCommand(ctx, cmd)
 }
 
 // RemovePolicies implements the http.Store interface.
-func (s *Store) RemovePolicies(request *command.RemovePoliciesRequest) error {
+func (s *Store) RemovePolicies(ctx context.Context, request *command.RemovePoliciesRequest) (*command.ApplyResponse, error) {
 	data, err := proto.Marshal(request)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	cmd := &command.Command{
 		Type: command.Command_COMMAND_TYPE_REMOVE_POLICIES,
 		Data: data,
 	}
-	return s.applyProtoMessage(cmd)
+	return s.applyCommand(ctx, cmd)
 }
 
 // RemoveFilteredPolicy implements the http.Store interface.
-func (s *Store) RemoveFilteredPolicy(request *command.RemoveFilteredPolicyRequest) error {
+func (s *Store) RemoveFilteredPolicy(ctx context.Context, request *command.RemoveFilteredPolicyRequest) (*command.ApplyResponse, error) {
 	data, err := proto.Marshal(request)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	cmd := &command.Command{
 		Type: command.Command_COMMAND_TYPE_REMOVE_FILTERED_POLICY,
 		Data: data,
 	}
-	return s.applyProtoMessage(cmd)
+	return s.applyCommand(ctx, cmd)
 }
 
 // UpdatePolicy implements the http.Store interface.
-func (s *Store) UpdatePolicy(request *command.UpdatePolicyRequest) error {
+func (s *Store) UpdatePolicy(ctx context.Context, request *command.UpdatePolicyRequest) (*command.ApplyResponse, error) {
 	data, err := proto.Marshal(request)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	cmd := &command.Command{
 		Type: command.Command_COMMAND_TYPE_UPDATE_POLICY,
 		Data: data,
 	}
-	return s.applyProtoMessage(cmd)
+	return s.applyCommand(ctx, cmd)
 }
 
 // UpdatePolicies implements the http.Store interface.
-func (s *Store) UpdatePolicies(request *command.UpdatePoliciesRequest) error {
+func (s *Store) UpdatePolicies(ctx context.Context, request *command.UpdatePoliciesRequest) (*command.ApplyResponse, error) {
 	data, err := proto.Marshal(request)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	cmd := &command.Command{
 		Type: command.Command_COMMAND_TYPE_UPDATE_POLICIES,
 		Data: data,
 	}
-	return s.applyProtoMessage(cmd)
+	return s.applyCommand(ctx, cmd)
 }
 
 // UpdateFilteredPolicies implements the http.Store interface.
-func (s *Store) UpdateFilteredPolicies(request *command.UpdateFilteredPoliciesRequest) error {
+func (s *Store) UpdateFilteredPolicies(ctx context.Context, request *command.UpdateFilteredPoliciesRequest) (*command.ApplyResponse, error) {
 	data, err := proto.Marshal(request)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	cmd := &command.Command{
 		Type: command.Command_COMMAND_TYPE_UPDATE_FILTERED_POLICIES,
 		Data: data,
 	}
-	return s.applyProtoMessage(cmd)
+	return s.applyCommand(ctx, cmd)
 }
 
 // ClearPolicy implements the http.Store interface.
-func (s *Store) ClearPolicy() error {
+func (s *Store) ClearPolicy(ctx context.Context) (*command.ApplyResponse, error) {
 	cmd := &command.Command{
 		Type: command.Command_COMMAND_TYPE_CLEAR_POLICY,
 		Data: nil,
 	}
-	return s.applyProtoMessage(cmd)
+	return s.applyCommand(ctx, cmd)
+}
+
+// expirePolicies removes the rules expired at the given unix time.
+func (s *Store) expirePolicies(ctx context.Context, now int64) (*command.ApplyResponse, error) {
+	data, err := proto.Marshal(&command.ExpirePoliciesRequest{Now: now})
+	if err != nil {
+		return nil, err
+	}
+	cmd := &command.Command{
+		Type: command.Command_COMMAND_TYPE_EXPIRE_POLICIES,
+		Data: data,
+	}
+	return s.applyCommand(ctx, cmd)
+}
+
+// SchedulePolicies implements the http.Store interface.
+func (s *Store) SchedulePolicies(ctx context.Context, request *command.SchedulePoliciesRequest) (*command.ApplyResponse, error) {
+	data, err := proto.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	cmd := &command.Command{
+		Type: command.Command_COMMAND_TYPE_SCHEDULE_POLICIES,
+		Data: data,
+	}
+	return s.applyCommand(ctx, cmd)
+}
+
+// ReschedulePolicies implements the http.Store interface.
+func (s *Store) ReschedulePolicies(ctx context.Context, request *command.ReschedulePoliciesRequest) (*command.ApplyResponse, error) {
+	data, err := proto.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	cmd := &command.Command{
+		Type: command.Command_COMMAND_TYPE_RESCHEDULE_POLICIES,
+		Data: data,
+	}
+	return s.applyCommand(ctx, cmd)
+}
+
+// CancelScheduledPolicies implements the http.Store interface.
+func (s *Store) CancelScheduledPolicies(ctx context.Context, request *command.CancelScheduledPoliciesRequest) (*command.ApplyResponse, error) {
+	data, err := proto.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	cmd := &command.Command{
+		Type: command.Command_COMMAND_TYPE_CANCEL_SCHEDULED_POLICIES,
+		Data: data,
+	}
+	return s.applyCommand(ctx, cmd)
+}
+
+// PendingPolicies implements the http.Store interface.
+func (s *Store) PendingPolicies() ([]*command.PendingPolicy, error) {
+	return s.fsm.PendingPolicies()
+}
+
+// BootstrapPolicies seeds the cluster with the initial policies in a single command.
+// The policies are added only if the cluster has not been bootstrapped yet.
+func (s *Store) BootstrapPolicies(ctx context.Context, request *command.BootstrapPoliciesRequest) (*command.ApplyResponse, error) {
+	data, err := proto.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	cmd := &command.Command{
+		Type: command.Command_COMMAND_TYPE_BOOTSTRAP_POLICIES,
+		Data: data,
+	}
+	return s.applyCommand(ctx, cmd)
+}
+
+// Bootstrapped reports whether the policies applied to the current node include the initial policies of the cluster.
+func (s *Store) Bootstrapped() (bool, error) {
+	return s.fsm.Bootstrapped()
+}
+
+// ImportPolicies implements the http.Store interface.
+func (s *Store) ImportPolicies(ctx context.Context, request *command.ImportPoliciesRequest) (*command.ApplyResponse, error) {
+	data, err := proto.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	cmd := &command.Command{
+		Type: command.Command_COMMAND_TYPE_IMPORT_POLICIES,
+		Data: data,
+	}
+	return s.applyCommand(ctx, cmd)
+}
+
+// ForEachPolicy implements the http.Store interface.
+func (s *Store) ForEachPolicy(fn func(policy *command.Policy) error) error {
+	return s.fsm.ForEachPolicy(fn)
+}
+
+// EnforceEx implements the http.Store interface.
+func (s *Store) EnforceEx(rvals ...interface{}) (bool, []string, error) {
+	return s.enforcer.EnforceEx(rvals...)
+}
+
+// Snapshot implements the http.Store interface.
+func (s *Store) Snapshot() (*raft.SnapshotMeta, io.ReadCloser, error) {
+	future := s.raft.Snapshot()
+	err := future.Error()
+	if err == nil {
+		return future.Open()
+	}
+	if err != raft.ErrNothingNewToSnapshot {
+		return nil, nil, err
+	}
+
+	snapshots, err := s.snapshotStore.List()
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(snapshots) == 0 {
+		return nil, nil, errors.New("there is no snapshot")
+	}
+	return s.snapshotStore.Open(snapshots[0].ID)
+}
+
+// ResyncSink replaces all rules of the sink with the rules applied to the current node.
+func (s *Store) ResyncSink() error {
+	if s.fsm.sink == nil {
+		return errors.New("the sink is not configured")
+	}
+	s.fsm.sink.Resync()
+	return nil
+}
+
+// Adapter returns a read-only Adapter over the policies replicated to the current node.
+func (s *Store) Adapter() *Adapter {
+	return s.fsm.Adapter()
+}
+
+// activatePolicies adds the pending rules due at the given unix time.
+func (s *Store) activatePolicies(ctx context.Context, now int64) (*command.ApplyResponse, error) {
+	data, err := proto.Marshal(&command.ActivatePoliciesRequest{Now: now})
+	if err != nil {
+		return nil, err
+	}
+	cmd := &command.Command{
+		Type: command.Command_COMMAND_TYPE_ACTIVATE_POLICIES,
+		Data: data,
+	}
+	return s.applyCommand(ctx, cmd)
+}
+
+// runScheduler proposes the commands to activate the pending rules and remove the expired rules
+// while the current node is the leader.
+// The time is carried by the commands, so that every node changes the same rules.
+func (s *Store) runScheduler() {
+	ticker := time.NewTicker(schedulerCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.shutdownCh:
+			return
+		case <-ticker.C:
+		}
+
+		if s.raft.State() != raft.Leader {
+			continue
+		}
+
+		now := time.Now().Unix()
+		next, ok, err := s.fsm.NextActivation()
+		if err != nil {
+			s.logger.Error("failed to get the next activation time", zap.Error(err))
+		} else if ok && next <= now {
+			_, err = s.activatePolicies(context.Background(), now)
+			if err != nil {
+				s.logger.Error("failed to activate the pending rules", zap.Error(err))
+			}
+		}
+
+		next, ok, err = s.fsm.NextExpiration()
+		if err != nil {
+			s.logger.Error("failed to get the next expiration time", zap.Error(err))
+		} else if ok && next <= now {
+			_, err = s.expirePolicies(context.Background(), now)
+			if err != nil {
+				s.logger.Error("failed to remove the expired rules", zap.Error(err))
+			}
+		}
+	}
+}
+
+// Transaction implements the http.Store interface.
+func (s *Store) Transaction(ctx context.Context, request *command.TransactionRequest) (*command.ApplyResponse, error) {
+	data, err := proto.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	cmd := &command.Command{
+		Type: command.Command_COMMAND_TYPE_TRANSACTION,
+		Data: data,
+	}
+	return s.applyCommand(ctx, cmd)
 }
 
 // JoinNode implements the http.Store interface.
-func (s *Store) JoinNode(serverID string, address string) error {
-	i := s.raft.AddVoter(raft.ServerID(serverID), raft.ServerAddress(address), 0, 0)
+// If a server with the given ID is in the cluster with another address, such as after it restarts on a new port,
+// its address is updated by the same configuration change, and the leader replicates the log to the new address.
+// A voter cannot rejoin as a nonvoter, raft ignores it, DemoteNode makes a voter a nonvoter.
+func (s *Store) JoinNode(serverID string, address string, role string) error {
+	server, err := s.getServer(serverID)
+	if err == nil && server.Address != raft.ServerAddress(address) {
+		s.logger.Info("the address of the server is changed", zap.String("serverID", serverID), zap.String("oldAddress", string(server.Address)), zap.String("newAddress", address))
+	}
+	if err == nil && server.Suffrage == raft.Voter && role == http.RoleNonvoter {
+		return fmt.Errorf("the server %s is a voter, demote it to make it a nonvoter", serverID)
+	}
+
+	var i raft.IndexFuture
+	if role == http.RoleNonvoter {
+		i = s.raft.AddNonvoter(raft.ServerID(serverID), raft.ServerAddress(address), 0, 0)
+	} else {
+		i = s.raft.AddVoter(raft.ServerID(serverID), raft.ServerAddress(address), 0, 0)
+	}
 	return i.Error()
 }
 
+// PromoteNode implements the http.Store interface.
+func (s *Store) PromoteNode(serverID string) error {
+	if !s.IsLeader() {
+		return raft.ErrNotLeader
+	}
+	server, err := s.getServer(serverID)
+	if err != nil {
+		return err
+	}
+	if server.Suffrage == raft.Voter {
+		return nil
+	}
+	i := s.raft.AddVoter(server.ID, server.Address, 0, 0)
+	return i.Error()
+}
+
+// DemoteNode implements the http.Store interface.
+func (s *Store) DemoteNode(serverID string) error {
+	if !s.IsLeader() {
+		return raft.ErrNotLeader
+	}
+	server, err := s.getServer(serverID)
+	if err != nil {
+		return err
+	}
+	if server.Suffrage != raft.Voter {
+		return nil
+	}
+	i := s.raft.DemoteVoter(server.ID, 0, 0)
+	return i.Error()
+}
+
+// Configuration returns the latest raft configuration, it is restored from DataDir when the node restarts.
+func (s *Store) Configuration() (raft.Configuration, error) {
+	future := s.raft.GetConfiguration()
+	err := future.Error()
+	if err != nil {
+		return raft.Configuration{}, err
+	}
+	return future.Configuration(), nil
+}
+
+// getServer returns the server with the given ID in the latest configuration.
+func (s *Store) getServer(serverID string) (*raft.Server, error) {
+	configuration, err := s.Configuration()
+	if err != nil {
+		return nil, err
+	}
+	for _, server := range configuration.Servers {
+		if server.ID == raft.ServerID(serverID) {
+			return &server, nil
+		}
+	}
+	return nil, fmt.Errorf("the server %s is not in the cluster", serverID)
+}
+
 // RemoveNode implements the http.Store interface.
 func (s *Store) RemoveNode(serverID string) error {
 	i := s.raft.RemoveServer(raft.ServerID(serverID), 0, 0)
 	return i.Error()
 }
 
+// TransferLeadership implements the http.Store interface.
+func (s *Store) TransferLeadership(serverID string) error {
+	if !s.IsLeader() {
+		return raft.ErrNotLeader
+	}
+
+	var transfer raft.Future
+	if len(serverID) == 0 {
+		transfer = s.raft.LeadershipTransfer()
+	} else {
+		server, err := s.getServer(serverID)
+		if err != nil {
+			return err
+		}
+		transfer = s.raft.LeadershipTransferToServer(server.ID, server.Address)
+	}
+	err := transfer.Error()
+	if err != nil {
+		return err
+	}
+
+	// the transfer is done once the target starts an election, wait until it wins.
+	return s.waitForLeaderChange(2 * s.raftConfig.ElectionTimeout)
+}
+
+// waitForLeaderChange blocks until the current node knows another server is the leader.
+func (s *Store) waitForLeaderChange(timeout time.Duration) error {
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		leader := s.raft.Leader()
+		if s.raft.State() != raft.Leader && len(leader) != 0 && leader != s.transport.LocalAddr() {
+			return nil
+		}
+		select {
+		case <-timer.C:
+			return errors.New("the new leader is not elected in time")
+		case <-ticker.C:
+		}
+	}
+}
+
+// IsLeader checks if the current node is the leader without waiting for a leader.
+func (s *Store) IsLeader() bool {
+	return s.raft.State() == raft.Leader
+}
+
 // Leader implements the http.Store interface.
 func (s *Store) Leader() (bool, string) {
 	_ = s.WaitLeader()
 	return s.raft.State() == raft.Leader, string(s.raft.Leader())
 }
 
+// Revision implements the http.Store interface.
+func (s *Store) Revision() uint64 {
+	return s.fsm.Revision()
+}
+
+// Barrier implements the http.Store interface.
+// The deadline of ctx bounds the time to enqueue the barrier, raftTimeout is used if ctx has no deadline.
+func (s *Store) Barrier(ctx context.Context) (uint64, error) {
+	err := waitFuture(ctx, s.raft.VerifyLeader())
+	if err != nil {
+		return 0, err
+	}
+
+	timeout, err := raftTimeoutOf(ctx)
+	if err != nil {
+		return 0, err
+	}
+	err = waitFuture(ctx, s.raft.Barrier(timeout))
+	if err != nil {
+		return 0, err
+	}
+
+	return s.raft.AppliedIndex(), nil
+}
+
+// raftTimeoutOf returns the time left before the deadline of ctx, or raftTimeout if ctx has no deadline.
+func raftTimeoutOf(ctx context.Context) (time.Duration, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return raftTimeout, nil
+	}
+	timeout := time.Until(deadline)
+	if timeout <= 0 {
+		return 0, context.DeadlineExceeded
+	}
+	return timeout, nil
+}
+
+// waitFuture waits for the future, or returns ctx.Err() if ctx is done first.
+func waitFuture(ctx context.Context, f raft.Future) error {
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- f.Error()
+	}()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err := <-errCh:
+		return err
+	}
+}
+
+// WaitForAppliedIndex implements the http.Store interface.
+func (s *Store) WaitForAppliedIndex(ctx context.Context, index uint64) error {
+	if s.raft.AppliedIndex() >= index {
+		return nil
+	}
+
+	ticker := time.NewTicker(applyCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if s.raft.AppliedIndex() >= index {
+				return nil
+			}
+		}
+	}
+}
+
+// Members implements the http.Store interface.
+func (s *Store) Members() ([]*command.Member, error) {
+	future := s.raft.GetConfiguration()
+	err := future.Error()
+	if err != nil {
+		return nil, err
+	}
+
+	leader := s.raft.Leader()
+	var members []*command.Member
+	for _, server := range future.Configuration().Servers {
+		members = append(members, &command.Member{
+			Id:       string(server.ID),
+			Address:  string(server.Address),
+			Suffrage: strings.ToLower(server.Suffrage.String()),
+			Leader:   len(leader) != 0 && server.Address == leader,
+		})
+	}
+	return members, nil
+}
+
+// NodeStatus implements the http.Store interface.
+func (s *Store) NodeStatus() *command.NodeStatus {
+	state := s.raft.State()
+	status := &command.NodeStatus{
+		Id:           s.serverID,
+		State:        strings.ToLower(state.String()),
+		AppliedIndex: s.raft.AppliedIndex(),
+		LastIndex:    s.raft.LastIndex(),
+	}
+	if state != raft.Leader {
+		lastContact := s.raft.LastContact()
+		if lastContact.IsZero() {
+			status.LastContact = -1
+		} else {
+			status.LastContact = time.Since(lastContact).Milliseconds()
+		}
+	}
+	return status
+}
+
 // Leader implements the http.Store interface.
 func (s *Store) Stats() (map[string]interface{}, error) {
 	result := map[string]interface{}{
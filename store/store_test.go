@@ -1,8 +1,10 @@
 package store
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"testing"
@@ -13,9 +15,11 @@ import (
 	"github.com/casbin/casbin/v2"
 
 	"github.com/casbin/hraft-dispatcher/command"
+	"github.com/casbin/hraft-dispatcher/http"
 	"github.com/casbin/hraft-dispatcher/store/mocks"
 	"github.com/golang/mock/gomock"
 	"github.com/hashicorp/raft"
+	"github.com/pkg/errors"
 	. "github.com/smartystreets/goconvey/convey"
 	"github.com/stretchr/testify/assert"
 )
@@ -159,8 +163,92 @@ func TestStore_SingleNode(t *testing.T) {
 			}
 
 			enforcer.EXPECT().AddPoliciesSelf(nil, sec, pType, originalRules).Return(originalRules, nil)
-			err := store.AddPolicies(request)
+			resp, err := store.AddPolicies(context.Background(), request)
 			So(err, ShouldBeNil)
+			So(resp.Index, ShouldBeGreaterThan, 0)
+		})
+
+		Convey("AddPolicy() with an expired context", func() {
+			request := &command.AddPoliciesRequest{
+				Sec:   "p",
+				PType: "p",
+				Rules: []*command.StringArray{{Items: []string{"role:admin", "/", "*"}}},
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			_, err := store.AddPolicies(ctx, request)
+			So(err, ShouldResemble, context.Canceled)
+		})
+
+		Convey("AddPolicy() with the expected revision", func() {
+			sec := "p"
+			pType := "p"
+			originalRules := [][]string{{"role:user", "/", "GET"}}
+			request := &command.AddPoliciesRequest{
+				Sec:   sec,
+				PType: pType,
+				Rules: []*command.StringArray{{Items: originalRules[0]}},
+			}
+
+			revision := store.Revision()
+			_, err := store.AddPolicies(http.WithExpectedRevision(context.Background(), revision+1), request)
+			So(err, ShouldNotBeNil)
+			conflictErr, ok := err.(*http.RevisionConflictError)
+			So(ok, ShouldBeTrue)
+			So(conflictErr.Actual, ShouldEqual, revision)
+
+			enforcer.EXPECT().AddPoliciesSelf(nil, sec, pType, originalRules).Return(originalRules, nil)
+			resp, err := store.AddPolicies(http.WithExpectedRevision(context.Background(), revision), request)
+			So(err, ShouldBeNil)
+			So(resp.Revision, ShouldEqual, revision+1)
+			So(store.Revision(), ShouldEqual, revision+1)
+		})
+
+		Convey("AddPolicy() with a request ID", func() {
+			sec := "p"
+			pType := "p"
+			originalRules := [][]string{{"role:user", "/", "PUT"}}
+			request := &command.AddPoliciesRequest{
+				Sec:   sec,
+				PType: pType,
+				Rules: []*command.StringArray{{Items: originalRules[0]}},
+			}
+
+			ctx := http.WithRequestID(context.Background(), "add-policy-1")
+			enforcer.EXPECT().AddPoliciesSelf(nil, sec, pType, originalRules).Return(originalRules, nil).Times(1)
+			resp, err := store.AddPolicies(ctx, request)
+			So(err, ShouldBeNil)
+			So(resp.Duplicate, ShouldBeFalse)
+
+			retried, err := store.AddPolicies(ctx, request)
+			So(err, ShouldBeNil)
+			So(retried.Duplicate, ShouldBeTrue)
+			So(retried.Index, ShouldEqual, resp.Index)
+			So(retried.Revision, ShouldEqual, resp.Revision)
+			So(retried.EffectedRules, ShouldHaveLength, 1)
+		})
+
+		Convey("AddPolicy() failed", func() {
+			sec := "p"
+			pType := "p"
+			originalRules := [][]string{{"role:admin", "/"}}
+			var rules []*command.StringArray
+			for _, rule := range originalRules {
+				rules = append(rules, &command.StringArray{Items: rule})
+			}
+
+			request := &command.AddPoliciesRequest{
+				Sec:   sec,
+				PType: pType,
+				Rules: rules,
+			}
+
+			enforcer.EXPECT().AddPoliciesSelf(nil, sec, pType, originalRules).Return(nil, errors.New("invalid rule"))
+			_, err := store.AddPolicies(context.Background(), request)
+			So(err, ShouldNotBeNil)
+			_, ok := err.(*http.ApplyError)
+			So(ok, ShouldBeTrue)
 		})
 
 		Convey("RemovePolicy()", func() {
@@ -179,7 +267,7 @@ func TestStore_SingleNode(t *testing.T) {
 			}
 
 			enforcer.EXPECT().RemovePoliciesSelf(nil, sec, pType, originalRules).Return(originalRules, nil)
-			err := store.RemovePolicies(request)
+			_, err := store.RemovePolicies(context.Background(), request)
 			So(err, ShouldBeNil)
 		})
 
@@ -198,8 +286,11 @@ func TestStore_SingleNode(t *testing.T) {
 			}
 
 			enforcer.EXPECT().RemoveFilteredPolicySelf(nil, sec, pType, fieldIndex, fieldValues).Return(effected, nil)
-			err := store.RemoveFilteredPolicy(request)
+			resp, err := store.RemoveFilteredPolicy(context.Background(), request)
 			So(err, ShouldBeNil)
+			So(resp.Effected, ShouldBeTrue)
+			So(resp.EffectedRules, ShouldHaveLength, 1)
+			So(resp.EffectedRules[0].Items, ShouldResemble, effected[0])
 		})
 
 		Convey("UpdatePolicy()", func() {
@@ -216,14 +307,69 @@ func TestStore_SingleNode(t *testing.T) {
 			}
 
 			enforcer.EXPECT().UpdatePolicySelf(nil, sec, pType, oldRule, newRule).Return(true, nil)
-			err := store.UpdatePolicy(request)
+			_, err := store.UpdatePolicy(context.Background(), request)
 			So(err, ShouldBeNil)
 		})
 
 		Convey("ClearPolicy()", func() {
 			enforcer.EXPECT().ClearPolicySelf(nil).Return(nil)
-			err := store.ClearPolicy()
+			_, err := store.ClearPolicy(context.Background())
+			So(err, ShouldBeNil)
+		})
+
+		Convey("BootstrapPolicies()", func() {
+			request := &command.BootstrapPoliciesRequest{
+				Policies: []*command.AddPoliciesRequest{
+					{Sec: "p", PType: "p", Rules: []*command.StringArray{{Items: []string{"role:admin", "/", "*"}}}},
+					{Sec: "g", PType: "g", Rules: []*command.StringArray{{Items: []string{"alice", "role:admin"}}}},
+				},
+			}
+
+			enforcer.EXPECT().AddPoliciesSelf(nil, "p", "p", [][]string{{"role:admin", "/", "*"}}).Return([][]string{{"role:admin", "/", "*"}}, nil)
+			enforcer.EXPECT().AddPoliciesSelf(nil, "g", "g", [][]string{{"alice", "role:admin"}}).Return([][]string{{"alice", "role:admin"}}, nil)
+			resp, err := store.BootstrapPolicies(context.Background(), request)
+			So(err, ShouldBeNil)
+			So(resp.Effected, ShouldBeTrue)
+
+			bootstrapped, err := store.Bootstrapped()
 			So(err, ShouldBeNil)
+			So(bootstrapped, ShouldBeTrue)
+
+			resp, err = store.BootstrapPolicies(context.Background(), request)
+			So(err, ShouldBeNil)
+			So(resp.Effected, ShouldBeFalse)
+		})
+
+		Convey("Barrier()", func() {
+			index, err := store.Barrier(context.Background())
+			So(err, ShouldBeNil)
+			So(index, ShouldBeGreaterThan, 0)
+
+			expired, cancelExpired := context.WithTimeout(context.Background(), -time.Second)
+			defer cancelExpired()
+			_, err = store.Barrier(expired)
+			So(err, ShouldResemble, context.DeadlineExceeded)
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			err = store.WaitForAppliedIndex(ctx, index)
+			So(err, ShouldBeNil)
+
+			err = store.WaitForAppliedIndex(ctx, index+100)
+			So(err, ShouldResemble, context.DeadlineExceeded)
+		})
+
+		Convey("Snapshot()", func() {
+			meta, rc, err := store.Snapshot()
+			So(err, ShouldBeNil)
+			So(rc.Close(), ShouldBeNil)
+			So(meta.Index, ShouldBeGreaterThan, 0)
+
+			// nothing is applied since the last snapshot, the snapshot has the same index.
+			latest, rc, err := store.Snapshot()
+			So(err, ShouldBeNil)
+			So(rc.Close(), ShouldBeNil)
+			So(latest.Index, ShouldEqual, meta.Index)
 		})
 
 		Convey("ID()", func() {
@@ -278,7 +424,7 @@ func TestStore_MultipleNode(t *testing.T) {
 	assert.NoError(t, err)
 	defer followerStore.Stop()
 
-	err = leaderStore.JoinNode(followerStore.ID(), followerStore.Address())
+	err = leaderStore.JoinNode(followerStore.ID(), followerStore.Address(), http.RoleVoter)
 	assert.NoError(t, err)
 
 	err = followerStore.WaitLeader()
@@ -302,7 +448,7 @@ func TestStore_MultipleNode(t *testing.T) {
 
 			leaderEnforcer.EXPECT().AddPoliciesSelf(nil, sec, pType, originalRules).Return(originalRules, nil)
 			followerEnforcer.EXPECT().AddPoliciesSelf(nil, sec, pType, originalRules).Return(originalRules, nil)
-			err := leaderStore.AddPolicies(request)
+			_, err := leaderStore.AddPolicies(context.Background(), request)
 			So(err, ShouldBeNil)
 
 			// Waiting for synchronization data to follow node.
@@ -326,7 +472,7 @@ func TestStore_MultipleNode(t *testing.T) {
 
 			leaderEnforcer.EXPECT().RemovePoliciesSelf(nil, sec, pType, originalRules).Return(originalRules, nil)
 			followerEnforcer.EXPECT().RemovePoliciesSelf(nil, sec, pType, originalRules).Return(originalRules, nil)
-			err := leaderStore.RemovePolicies(request)
+			_, err := leaderStore.RemovePolicies(context.Background(), request)
 			So(err, ShouldBeNil)
 
 			// Waiting for synchronization data to follow node.
@@ -349,7 +495,7 @@ func TestStore_MultipleNode(t *testing.T) {
 
 			leaderEnforcer.EXPECT().RemoveFilteredPolicySelf(nil, sec, pType, fieldIndex, fieldValues).Return(effected, nil)
 			followerEnforcer.EXPECT().RemoveFilteredPolicySelf(nil, sec, pType, fieldIndex, fieldValues).Return(effected, nil)
-			err := leaderStore.RemoveFilteredPolicy(request)
+			_, err := leaderStore.RemoveFilteredPolicy(context.Background(), request)
 			So(err, ShouldBeNil)
 
 			// Waiting for synchronization data to follow node.
@@ -371,7 +517,7 @@ func TestStore_MultipleNode(t *testing.T) {
 
 			leaderEnforcer.EXPECT().UpdatePolicySelf(nil, sec, pType, oldRule, newRule).Return(true, nil)
 			followerEnforcer.EXPECT().UpdatePolicySelf(nil, sec, pType, oldRule, newRule).Return(true, nil)
-			err := leaderStore.UpdatePolicy(request)
+			_, err := leaderStore.UpdatePolicy(context.Background(), request)
 			So(err, ShouldBeNil)
 
 			// Waiting for synchronization data to follow node.
@@ -381,7 +527,7 @@ func TestStore_MultipleNode(t *testing.T) {
 		Convey("ClearPolicy()", func() {
 			leaderEnforcer.EXPECT().ClearPolicySelf(nil).Return(nil)
 			followerEnforcer.EXPECT().ClearPolicySelf(nil).Return(nil)
-			err := leaderStore.ClearPolicy()
+			_, err := leaderStore.ClearPolicy(context.Background())
 			So(err, ShouldBeNil)
 
 			// Waiting for synchronization data to follow node.
@@ -408,6 +554,93 @@ func TestStore_MultipleNode(t *testing.T) {
 			So(address, ShouldEqual, leaderAddress)
 		})
 
+		Convey("Barrier()", func() {
+			index, err := leaderStore.Barrier(context.Background())
+			So(err, ShouldBeNil)
+			So(index, ShouldBeGreaterThan, 0)
+
+			_, err = followerStore.Barrier(context.Background())
+			So(err, ShouldEqual, raft.ErrNotLeader)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			err = followerStore.WaitForAppliedIndex(ctx, index)
+			So(err, ShouldBeNil)
+		})
+
+		Convey("Members()", func() {
+			members, err := followerStore.Members()
+			So(err, ShouldBeNil)
+			So(members, ShouldHaveLength, 2)
+			for _, member := range members {
+				So(member.Suffrage, ShouldEqual, http.RoleVoter)
+				So(member.Leader, ShouldEqual, member.Address == leaderAddress)
+			}
+		})
+
+		Convey("NodeStatus()", func() {
+			index, err := leaderStore.Barrier(context.Background())
+			So(err, ShouldBeNil)
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			err = followerStore.WaitForAppliedIndex(ctx, index)
+			So(err, ShouldBeNil)
+
+			status := leaderStore.NodeStatus()
+			So(status.Id, ShouldEqual, leaderStore.ID())
+			So(status.State, ShouldEqual, "leader")
+			So(status.AppliedIndex, ShouldBeGreaterThanOrEqualTo, index)
+			So(status.LastContact, ShouldEqual, 0)
+
+			status = followerStore.NodeStatus()
+			So(status.State, ShouldEqual, "follower")
+			So(status.AppliedIndex, ShouldBeGreaterThanOrEqualTo, index)
+			So(status.LastContact, ShouldBeGreaterThanOrEqualTo, 0)
+		})
+
+		Convey("JoinNode()", func() {
+			// the add fails on a follower, the server keeps its address.
+			err := followerStore.JoinNode(followerStore.ID(), localIP+":6800", http.RoleVoter)
+			So(err, ShouldEqual, raft.ErrNotLeader)
+			server, err := leaderStore.getServer(followerStore.ID())
+			So(err, ShouldBeNil)
+			So(server.Address, ShouldEqual, raft.ServerAddress(followerAddress))
+			So(server.Suffrage, ShouldEqual, raft.Voter)
+
+			err = leaderStore.JoinNode(followerStore.ID(), followerStore.Address(), http.RoleVoter)
+			So(err, ShouldBeNil)
+			configuration, err := leaderStore.Configuration()
+			So(err, ShouldBeNil)
+			So(configuration.Servers, ShouldHaveLength, 2)
+
+			// a voter is not demoted by joining as a nonvoter.
+			err = leaderStore.JoinNode(followerStore.ID(), followerStore.Address(), http.RoleNonvoter)
+			So(err, ShouldBeError, fmt.Sprintf("the server %s is a voter, demote it to make it a nonvoter", followerStore.ID()))
+			server, err = leaderStore.getServer(followerStore.ID())
+			So(err, ShouldBeNil)
+			So(server.Suffrage, ShouldEqual, raft.Voter)
+		})
+
+		Convey("DemoteNode() and PromoteNode()", func() {
+			err := followerStore.DemoteNode(followerStore.ID())
+			So(err, ShouldEqual, raft.ErrNotLeader)
+
+			err = leaderStore.DemoteNode(followerStore.ID())
+			So(err, ShouldBeNil)
+			server, err := leaderStore.getServer(followerStore.ID())
+			So(err, ShouldBeNil)
+			So(server.Suffrage, ShouldEqual, raft.Nonvoter)
+
+			err = leaderStore.PromoteNode(followerStore.ID())
+			So(err, ShouldBeNil)
+			server, err = leaderStore.getServer(followerStore.ID())
+			So(err, ShouldBeNil)
+			So(server.Suffrage, ShouldEqual, raft.Voter)
+
+			err = leaderStore.PromoteNode("unknown")
+			So(err, ShouldBeError, "the server unknown is not in the cluster")
+		})
+
 		Convey("RemoveNode()", func() {
 			err := leaderStore.RemoveNode(followerAddress)
 			So(err, ShouldBeNil)
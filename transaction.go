@@ -0,0 +1,140 @@
+package hraftdispatcher
+
+import (
+	"context"
+	"time"
+
+	"github.com/casbin/hraft-dispatcher/command"
+)
+
+// Transaction holds a set of operations that are applied atomically by HRaftDispatcher.Transaction.
+// The operations are applied in the order they are added, if any of them fails, none of them is applied.
+type Transaction struct {
+	request *command.TransactionRequest
+}
+
+// NewTransaction returns an empty Transaction.
+func NewTransaction() *Transaction {
+	return &Transaction{request: &command.TransactionRequest{}}
+}
+
+// AddPolicies adds an operation to add a set of rules.
+func (t *Transaction) AddPolicies(sec string, pType string, rules [][]string) *Transaction {
+	return t.add(&command.TransactionOperation{
+		AddPolicies: &command.AddPoliciesRequest{
+			Sec:   sec,
+			PType: pType,
+			Rules: newStringArrays(rules),
+		},
+	})
+}
+
+// AddExpiringPolicies adds an operation to add a set of rules that are removed by the cluster at the given time.
+// expiresAt has the same length as rules, a zero time means the rule never expires.
+func (t *Transaction) AddExpiringPolicies(sec string, pType string, rules [][]string, expiresAt []time.Time) *Transaction {
+	return t.add(&command.TransactionOperation{
+		AddPolicies: &command.AddPoliciesRequest{
+			Sec:       sec,
+			PType:     pType,
+			Rules:     newStringArrays(rules),
+			ExpiresAt: newUnixTimes(expiresAt),
+		},
+	})
+}
+
+// RemovePolicies adds an operation to remove a set of rules.
+func (t *Transaction) RemovePolicies(sec string, pType string, rules [][]string) *Transaction {
+	return t.add(&command.TransactionOperation{
+		RemovePolicies: &command.RemovePoliciesRequest{
+			Sec:   sec,
+			PType: pType,
+			Rules: newStringArrays(rules),
+		},
+	})
+}
+
+// RemoveFilteredPolicy adds an operation to remove the rules that match a pattern.
+func (t *Transaction) RemoveFilteredPolicy(sec string, pType string, fieldIndex int, fieldValues ...string) *Transaction {
+	return t.add(&command.TransactionOperation{
+		RemoveFilteredPolicy: &command.RemoveFilteredPolicyRequest{
+			Sec:         sec,
+			PType:       pType,
+			FieldIndex:  int32(fieldIndex),
+			FieldValues: fieldValues,
+		},
+	})
+}
+
+// UpdatePolicy adds an operation to replace an existing rule.
+func (t *Transaction) UpdatePolicy(sec string, pType string, oldRule, newRule []string) *Transaction {
+	return t.add(&command.TransactionOperation{
+		UpdatePolicy: &command.UpdatePolicyRequest{
+			Sec:     sec,
+			PType:   pType,
+			OldRule: oldRule,
+			NewRule: newRule,
+		},
+	})
+}
+
+// UpdatePolicies adds an operation to replace a set of existing rules.
+func (t *Transaction) UpdatePolicies(sec string, pType string, oldRules, newRules [][]string) *Transaction {
+	return t.add(&command.TransactionOperation{
+		UpdatePolicies: &command.UpdatePoliciesRequest{
+			Sec:      sec,
+			PType:    pType,
+			OldRules: newStringArrays(oldRules),
+			NewRules: newStringArrays(newRules),
+		},
+	})
+}
+
+// UpdateFilteredPolicies adds an operation to replace a set of existing rules.
+func (t *Transaction) UpdateFilteredPolicies(sec string, pType string, oldRules, newRules [][]string) *Transaction {
+	return t.add(&command.TransactionOperation{
+		UpdateFilteredPolicies: &command.UpdateFilteredPoliciesRequest{
+			Sec:      sec,
+			PType:    pType,
+			OldRules: newStringArrays(oldRules),
+			NewRules: newStringArrays(newRules),
+		},
+	})
+}
+
+// ClearPolicy adds an operation to clear all rules.
+func (t *Transaction) ClearPolicy() *Transaction {
+	return t.add(&command.TransactionOperation{ClearPolicy: true})
+}
+
+func (t *Transaction) add(op *command.TransactionOperation) *Transaction {
+	t.request.Operations = append(t.request.Operations, op)
+	return t
+}
+
+// Transaction applies the operations of txn atomically in a single raft log entry.
+// The Results of the response hold the result of each operation in order.
+func (h *HRaftDispatcher) Transaction(ctx context.Context, txn *Transaction) (*command.ApplyResponse, error) {
+	return h.handleApplyResponse(h.httpService.DoTransactionRequest(ctx, txn.request))
+}
+
+// newStringArrays converts a set of rules to StringArray.
+func newStringArrays(rules [][]string) []*command.StringArray {
+	var items []*command.StringArray
+	for _, rule := range rules {
+		items = append(items, &command.StringArray{Items: rule})
+	}
+	return items
+}
+
+// newUnixTimes converts a set of time to unix seconds, the zero time is converted to 0.
+func newUnixTimes(times []time.Time) []int64 {
+	var items []int64
+	for _, t := range times {
+		if t.IsZero() {
+			items = append(items, 0)
+		} else {
+			items = append(items, t.Unix())
+		}
+	}
+	return items
+}